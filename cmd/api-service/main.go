@@ -2,16 +2,22 @@ package main
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/Raisondetr3/checklist-api-service/internal/auth"
 	"github.com/Raisondetr3/checklist-api-service/internal/client"
 	"github.com/Raisondetr3/checklist-api-service/internal/config"
+	"github.com/Raisondetr3/checklist-api-service/internal/events"
+	"github.com/Raisondetr3/checklist-api-service/internal/history"
 	"github.com/Raisondetr3/checklist-api-service/internal/service"
 	httpTransport "github.com/Raisondetr3/checklist-api-service/internal/transport/http"
+	"github.com/Raisondetr3/checklist-api-service/internal/transport/http/middleware"
 	"github.com/Raisondetr3/checklist-api-service/pkg/logger"
 )
 
@@ -49,10 +55,94 @@ func main() {
 		}
 	}()
 
-	taskService := service.NewTaskService(grpcClient)
-	healthService := service.NewHealthService(cfg)
+	var publisher events.Publisher = events.NewNopPublisher()
+	var outbox *events.Outbox
+	var outboxDepth service.QueueDepthReporter
+	if cfg.ExternalServices.Kafka.Enabled {
+		kafkaPublisher, err := events.NewKafkaPublisher(cfg.ExternalServices.Kafka)
+		if err != nil {
+			slog.Error("Failed to create Kafka publisher", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+
+		outbox, err = events.NewOutbox(cfg.ExternalServices.Kafka.Outbox, kafkaPublisher)
+		if err != nil {
+			slog.Error("Failed to open event outbox", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+
+		publisher = outbox
+		outboxDepth = outbox
+	}
+	defer func() {
+		if err := publisher.Close(); err != nil {
+			slog.Error("Failed to close event publisher", slog.String("error", err.Error()))
+		}
+	}()
+
+	historyStore, err := history.NewStore(cfg.History)
+	if err != nil {
+		slog.Error("Failed to open task history store", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	if closer, ok := historyStore.(io.Closer); ok {
+		defer func() {
+			if err := closer.Close(); err != nil {
+				slog.Error("Failed to close task history store", slog.String("error", err.Error()))
+			}
+		}()
+	}
+
+	taskService := service.NewTaskService(grpcClient, cfg.ExternalServices.DBService.Capabilities, cfg.Export, publisher, historyStore)
+	itemService := service.NewChecklistItemService(grpcClient, historyStore)
+	listService := service.NewListService(grpcClient, taskService, publisher, historyStore)
+	shareService := service.NewShareService(grpcClient)
+	healthService := service.NewHealthService(cfg, outboxDepth)
 
-	handlers := httpTransport.NewHTTPHandlers(cfg, taskService, healthService)
+	if cfg.Trash.PurgeEnabled {
+		purger := service.NewTrashPurger(grpcClient, historyStore, cfg.Trash)
+		purger.Start()
+		defer purger.Stop()
+	}
+
+	var authenticator *middleware.Authenticator
+	var apiKeys auth.APIKeyStore
+	if cfg.Auth.Enabled {
+		apiKeyStore, err := auth.NewFileAPIKeyStore(cfg.Auth.APIKeys.StoreFile)
+		if err != nil {
+			slog.Error("Failed to open API key store", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		apiKeys = apiKeyStore
+
+		if secret := cfg.Auth.APIKeys.BootstrapAdminKey; secret != "" {
+			added, err := apiKeyStore.Bootstrap(secret)
+			if err != nil {
+				slog.Error("Failed to add bootstrap admin API key", slog.String("error", err.Error()))
+				os.Exit(1)
+			}
+			if added {
+				slog.Info("Bootstrap admin API key added", slog.String("file", cfg.Auth.APIKeys.StoreFile))
+			}
+		}
+
+		authenticator, err = middleware.NewAuthenticator(cfg.Auth, apiKeys)
+		if errors.Is(err, auth.ErrNoCredentials) {
+			slog.Error("Failed to configure authentication",
+				slog.String("error", err.Error()),
+				slog.String("hint", "set JWT_HMAC_SECRET, JWT_RSA_PUBLIC_KEY_FILE, JWT_JWKS_FILE or API_KEYS_BOOTSTRAP_ADMIN_KEY"),
+			)
+			os.Exit(1)
+		}
+		if err != nil {
+			slog.Error("Failed to configure authentication", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+	} else {
+		slog.Warn("Authentication is disabled by AUTH_ENABLED=false; API endpoints are publicly accessible")
+	}
+
+	handlers := httpTransport.NewHTTPHandlers(cfg, taskService, itemService, listService, shareService, healthService, authenticator, apiKeys)
 	server := httpTransport.NewHTTPServer(cfg, handlers)
 
 	go func() {
@@ -77,5 +167,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	if outbox != nil {
+		if err := outbox.Flush(ctx); err != nil {
+			slog.Error("Failed to flush event outbox", slog.String("error", err.Error()))
+		}
+	}
+
 	slog.Info("Server exited")
 }
\ No newline at end of file
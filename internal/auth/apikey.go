@@ -0,0 +1,250 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	apiKeyPrefix       = "ck_"
+	apiKeyDisplayChars = 10
+	minBootstrapLength = 32
+
+	bootstrapKeyName = "bootstrap-admin"
+)
+
+var (
+	ErrAPIKeyNotFound     = errors.New("api key not found")
+	ErrAPIKeyInvalid      = errors.New("api key is invalid or revoked")
+	ErrBootstrapKeyFormat = fmt.Errorf("bootstrap api key must start with %q and be at least %d characters", apiKeyPrefix, minBootstrapLength)
+)
+
+type APIKey struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	Hash      string     `json:"hash"`
+	Scopes    []string   `json:"scopes"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+func (k APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+func (k APIKey) Principal() Principal {
+	return Principal{
+		Subject: "apikey:" + k.ID,
+		Method:  MethodAPIKey,
+		Scopes:  k.Scopes,
+	}
+}
+
+type APIKeyStore interface {
+	Create(name string, scopes []string) (APIKey, string, error)
+	List() []APIKey
+	Revoke(id string) (APIKey, error)
+	Authenticate(secret string) (APIKey, error)
+}
+
+// FileAPIKeyStore keeps API keys in a JSON file. Only the SHA-256 hash of each
+// key is stored; the plaintext is returned once, when the key is created.
+type FileAPIKeyStore struct {
+	path string
+
+	mu     sync.RWMutex
+	keys   []APIKey
+	byHash map[string]int
+}
+
+func NewFileAPIKeyStore(path string) (*FileAPIKeyStore, error) {
+	s := &FileAPIKeyStore{
+		path:   path,
+		byHash: make(map[string]int),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read api key store: %w", err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &s.keys); err != nil {
+			return nil, fmt.Errorf("failed to parse api key store: %w", err)
+		}
+	}
+
+	for i, key := range s.keys {
+		s.byHash[key.Hash] = i
+	}
+
+	return s, nil
+}
+
+func (s *FileAPIKeyStore) Create(name string, scopes []string) (APIKey, string, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return APIKey{}, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	secret := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(random)
+
+	key := APIKey{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Prefix:    secret[:apiKeyDisplayChars],
+		Hash:      HashAPIKey(secret),
+		Scopes:    slices.Clone(scopes),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys = append(s.keys, key)
+	s.byHash[key.Hash] = len(s.keys) - 1
+
+	if err := s.save(); err != nil {
+		s.keys = s.keys[:len(s.keys)-1]
+		delete(s.byHash, key.Hash)
+		return APIKey{}, "", err
+	}
+
+	return key, secret, nil
+}
+
+// Bootstrap adds secret as an admin key unless the store already knows it.
+// It reports whether a key was added; a bootstrap key that was revoked
+// stays revoked.
+func (s *FileAPIKeyStore) Bootstrap(secret string) (bool, error) {
+	if !strings.HasPrefix(secret, apiKeyPrefix) || len(secret) < minBootstrapLength {
+		return false, ErrBootstrapKeyFormat
+	}
+
+	key := APIKey{
+		ID:        uuid.New().String(),
+		Name:      bootstrapKeyName,
+		Prefix:    secret[:apiKeyDisplayChars],
+		Hash:      HashAPIKey(secret),
+		Scopes:    []string{ScopeAdmin},
+		CreatedAt: time.Now().UTC(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.byHash[key.Hash]; ok {
+		return false, nil
+	}
+
+	s.keys = append(s.keys, key)
+	s.byHash[key.Hash] = len(s.keys) - 1
+
+	if err := s.save(); err != nil {
+		s.keys = s.keys[:len(s.keys)-1]
+		delete(s.byHash, key.Hash)
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *FileAPIKeyStore) List() []APIKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Clone(s.keys)
+}
+
+func (s *FileAPIKeyStore) Revoke(id string) (APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.keys {
+		if s.keys[i].ID != id {
+			continue
+		}
+		if s.keys[i].IsRevoked() {
+			return s.keys[i], nil
+		}
+
+		now := time.Now().UTC()
+		s.keys[i].RevokedAt = &now
+		if err := s.save(); err != nil {
+			s.keys[i].RevokedAt = nil
+			return APIKey{}, err
+		}
+		return s.keys[i], nil
+	}
+
+	return APIKey{}, ErrAPIKeyNotFound
+}
+
+func (s *FileAPIKeyStore) Authenticate(secret string) (APIKey, error) {
+	if !strings.HasPrefix(secret, apiKeyPrefix) {
+		return APIKey{}, ErrAPIKeyInvalid
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i, ok := s.byHash[HashAPIKey(secret)]
+	if !ok || s.keys[i].IsRevoked() {
+		return APIKey{}, ErrAPIKeyInvalid
+	}
+
+	return s.keys[i], nil
+}
+
+func (s *FileAPIKeyStore) save() error {
+	data, err := json.MarshalIndent(s.keys, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal api keys: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create api key store directory: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write api key store: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write api key store: %w", err)
+	}
+
+	return nil
+}
+
+// HasActiveKey reports whether any key in the store can still authenticate.
+func HasActiveKey(store APIKeyStore) bool {
+	if store == nil {
+		return false
+	}
+	for _, key := range store.List() {
+		if !key.IsRevoked() {
+			return true
+		}
+	}
+	return false
+}
+
+// HashAPIKey returns the hex-encoded SHA-256 digest under which a key is
+// stored. Operators can use it to seed the store file by hand.
+func HashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,187 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const testBootstrapKey = "ck_bootstrap-secret-for-tests-0123456789"
+
+func openTestKeyStore(t *testing.T, path string) *FileAPIKeyStore {
+	t.Helper()
+
+	store, err := NewFileAPIKeyStore(path)
+	if err != nil {
+		t.Fatalf("NewFileAPIKeyStore: %v", err)
+	}
+	return store
+}
+
+func TestFileAPIKeyStoreAuthenticate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_keys.json")
+	store := openTestKeyStore(t, path)
+
+	active, activeSecret, err := store.Create("ci", []string{ScopeTasksRead})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	revoked, revokedSecret, err := store.Create("old", []string{ScopeTasksWrite})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := store.Revoke(revoked.ID); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		secret  string
+		wantID  string
+		wantErr error
+	}{
+		{name: "active key", secret: activeSecret, wantID: active.ID},
+		{name: "revoked key", secret: revokedSecret, wantErr: ErrAPIKeyInvalid},
+		{name: "unknown key", secret: apiKeyPrefix + "unknown", wantErr: ErrAPIKeyInvalid},
+		{name: "missing prefix", secret: strings.TrimPrefix(activeSecret, apiKeyPrefix), wantErr: ErrAPIKeyInvalid},
+	}
+
+	// Keys and revocations must survive a reload from disk.
+	reopened := openTestKeyStore(t, path)
+
+	for _, tt := range tests {
+		for name, s := range map[string]*FileAPIKeyStore{"live": store, "reopened": reopened} {
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				key, err := s.Authenticate(tt.secret)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				if key.ID != tt.wantID {
+					t.Errorf("key id = %q, want %q", key.ID, tt.wantID)
+				}
+			})
+		}
+	}
+}
+
+func TestFileAPIKeyStoreStoresOnlyHashes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_keys.json")
+	store := openTestKeyStore(t, path)
+
+	key, secret, err := store.Create("ci", DefaultScopes)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), secret) {
+		t.Error("store file contains the plaintext key")
+	}
+	if key.Hash != HashAPIKey(secret) {
+		t.Errorf("hash = %q, want %q", key.Hash, HashAPIKey(secret))
+	}
+	if !strings.HasPrefix(secret, key.Prefix) || len(key.Prefix) != apiKeyDisplayChars {
+		t.Errorf("prefix = %q, want the first %d characters of the key", key.Prefix, apiKeyDisplayChars)
+	}
+}
+
+func TestFileAPIKeyStoreRevoke(t *testing.T) {
+	store := openTestKeyStore(t, filepath.Join(t.TempDir(), "api_keys.json"))
+
+	key, _, err := store.Create("ci", DefaultScopes)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	first, err := store.Revoke(key.ID)
+	if err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if !first.IsRevoked() {
+		t.Fatal("key is not revoked")
+	}
+
+	// Revoking again keeps the original revocation time.
+	second, err := store.Revoke(key.ID)
+	if err != nil {
+		t.Fatalf("second Revoke: %v", err)
+	}
+	if !second.RevokedAt.Equal(*first.RevokedAt) {
+		t.Errorf("revoked_at changed from %v to %v", first.RevokedAt, second.RevokedAt)
+	}
+
+	if _, err := store.Revoke("missing"); !errors.Is(err, ErrAPIKeyNotFound) {
+		t.Errorf("Revoke(missing) error = %v, want %v", err, ErrAPIKeyNotFound)
+	}
+	if HasActiveKey(store) {
+		t.Error("HasActiveKey = true with only revoked keys")
+	}
+}
+
+func TestFileAPIKeyStoreBootstrap(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		setup     func(s *FileAPIKeyStore)
+		wantAdded bool
+		wantErr   error
+	}{
+		{name: "empty store", secret: testBootstrapKey, wantAdded: true},
+		{
+			name:   "already present",
+			secret: testBootstrapKey,
+			setup: func(s *FileAPIKeyStore) {
+				s.Bootstrap(testBootstrapKey)
+			},
+		},
+		{
+			name:   "revoked key stays revoked",
+			secret: testBootstrapKey,
+			setup: func(s *FileAPIKeyStore) {
+				s.Bootstrap(testBootstrapKey)
+				s.Revoke(s.List()[0].ID)
+			},
+		},
+		{name: "missing prefix", secret: strings.Repeat("x", 40), wantErr: ErrBootstrapKeyFormat},
+		{name: "too short", secret: "ck_short", wantErr: ErrBootstrapKeyFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "api_keys.json")
+			store := openTestKeyStore(t, path)
+			if tt.setup != nil {
+				tt.setup(store)
+			}
+			before := len(store.List())
+
+			added, err := store.Bootstrap(tt.secret)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if added != tt.wantAdded {
+				t.Errorf("added = %v, want %v", added, tt.wantAdded)
+			}
+			if !tt.wantAdded {
+				if got := len(store.List()); got != before {
+					t.Errorf("store has %d keys, want %d", got, before)
+				}
+				return
+			}
+
+			key, err := openTestKeyStore(t, path).Authenticate(tt.secret)
+			if err != nil {
+				t.Fatalf("Authenticate after reopen: %v", err)
+			}
+			if !slices.Equal(key.Scopes, []string{ScopeAdmin}) {
+				t.Errorf("scopes = %v, want [%s]", key.Scopes, ScopeAdmin)
+			}
+		})
+	}
+}
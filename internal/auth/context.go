@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+const (
+	MethodJWT    = "jwt"
+	MethodAPIKey = "api_key"
+)
+
+const (
+	ScopeTasksRead   = "tasks:read"
+	ScopeTasksWrite  = "tasks:write"
+	ScopeTasksDelete = "tasks:delete"
+	ScopeAdmin       = "admin"
+)
+
+var (
+	Scopes        = []string{ScopeTasksRead, ScopeTasksWrite, ScopeTasksDelete, ScopeAdmin}
+	DefaultScopes = []string{ScopeTasksRead, ScopeTasksWrite, ScopeTasksDelete}
+)
+
+type Principal struct {
+	Subject string
+	Method  string
+	Scopes  []string
+}
+
+func (p Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope) || slices.Contains(p.Scopes, ScopeAdmin)
+}
+
+func (p Principal) IsAdmin() bool {
+	return slices.Contains(p.Scopes, ScopeAdmin)
+}
+
+func IsValidScope(scope string) bool {
+	return slices.Contains(Scopes, scope)
+}
+
+type contextKey string
+
+const principalKey contextKey = "principal"
+
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey).(Principal)
+	return principal, ok
+}
+
+func SubjectFromContext(ctx context.Context) string {
+	principal, _ := PrincipalFromContext(ctx)
+	return principal.Subject
+}
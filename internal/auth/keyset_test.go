@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func writeTestFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func generateRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func rsaPublicPEM(t *testing.T, key *rsa.PublicKey) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) jwk {
+	return jwk{
+		Kty: "RSA",
+		Kid: kid,
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func writeJWKS(t *testing.T, keys ...jwk) string {
+	t.Helper()
+
+	data, err := json.Marshal(jwks{Keys: keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return writeTestFile(t, "jwks.json", data)
+}
+
+func TestLoadKeySet(t *testing.T) {
+	rsaKey := generateRSAKey(t)
+
+	tests := []struct {
+		name     string
+		cfg      func(t *testing.T) config.JWTConfig
+		wantErr  error
+		wantFail bool
+		wantHMAC int
+		wantRSA  int
+	}{
+		{
+			name:    "nothing configured",
+			cfg:     func(t *testing.T) config.JWTConfig { return config.JWTConfig{} },
+			wantErr: ErrNoKeysConfigured,
+		},
+		{
+			name:     "hmac secret",
+			cfg:      func(t *testing.T) config.JWTConfig { return config.JWTConfig{HMACSecret: "secret"} },
+			wantHMAC: 1,
+		},
+		{
+			name: "rsa public key file",
+			cfg: func(t *testing.T) config.JWTConfig {
+				return config.JWTConfig{RSAPublicKeyFile: writeTestFile(t, "key.pem", rsaPublicPEM(t, &rsaKey.PublicKey))}
+			},
+			wantRSA: 1,
+		},
+		{
+			name: "invalid rsa public key",
+			cfg: func(t *testing.T) config.JWTConfig {
+				return config.JWTConfig{RSAPublicKeyFile: writeTestFile(t, "key.pem", []byte("not a key"))}
+			},
+			wantFail: true,
+		},
+		{
+			name: "jwks skips encryption keys",
+			cfg: func(t *testing.T) config.JWTConfig {
+				return config.JWTConfig{JWKSFile: writeJWKS(t,
+					rsaJWK("sig-1", &rsaKey.PublicKey),
+					jwk{Kty: "oct", Kid: "hmac-1", K: base64.RawURLEncoding.EncodeToString([]byte("secret"))},
+					jwk{Kty: "oct", Kid: "enc-1", Use: "enc", K: "c2VjcmV0"},
+				)}
+			},
+			wantHMAC: 1,
+			wantRSA:  1,
+		},
+		{
+			name: "jwks with only encryption keys",
+			cfg: func(t *testing.T) config.JWTConfig {
+				return config.JWTConfig{JWKSFile: writeJWKS(t, jwk{Kty: "oct", Kid: "enc-1", Use: "enc", K: "c2VjcmV0"})}
+			},
+			wantErr: ErrNoKeysConfigured,
+		},
+		{
+			name: "malformed jwks",
+			cfg: func(t *testing.T) config.JWTConfig {
+				return config.JWTConfig{JWKSFile: writeTestFile(t, "jwks.json", []byte("{"))}
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks, err := LoadKeySet(tt.cfg(t))
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			case tt.wantFail:
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			case err != nil:
+				t.Fatalf("LoadKeySet: %v", err)
+			}
+
+			if len(ks.hmac) != tt.wantHMAC || len(ks.rsa) != tt.wantRSA {
+				t.Errorf("loaded %d hmac and %d rsa keys, want %d and %d", len(ks.hmac), len(ks.rsa), tt.wantHMAC, tt.wantRSA)
+			}
+		})
+	}
+}
+
+func TestKeySetKeyfunc(t *testing.T) {
+	rsaA := generateRSAKey(t)
+	rsaB := generateRSAKey(t)
+
+	ks := &KeySet{
+		hmac: map[string][]byte{"h1": []byte("secret")},
+		rsa:  map[string]*rsa.PublicKey{"a": &rsaA.PublicKey, "b": &rsaB.PublicKey},
+	}
+
+	tests := []struct {
+		name    string
+		method  jwt.SigningMethod
+		kid     string
+		want    any
+		wantErr error
+	}{
+		{name: "rsa by kid", method: jwt.SigningMethodRS256, kid: "b", want: &rsaB.PublicKey},
+		{name: "hmac by kid", method: jwt.SigningMethodHS256, kid: "h1", want: []byte("secret")},
+		{name: "single hmac key without kid", method: jwt.SigningMethodHS256, want: []byte("secret")},
+		{name: "ambiguous rsa key without kid", method: jwt.SigningMethodRS256, wantErr: ErrUnknownKey},
+		{name: "unknown kid", method: jwt.SigningMethodRS256, kid: "c", wantErr: ErrUnknownKey},
+		{name: "kid of another key type", method: jwt.SigningMethodHS256, kid: "a", wantErr: ErrUnknownKey},
+		{name: "unsupported algorithm", method: jwt.SigningMethodRS512, kid: "a", wantErr: ErrUnknownKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := jwt.New(tt.method)
+			if tt.kid != "" {
+				token.Header["kid"] = tt.kid
+			}
+
+			got, err := ks.Keyfunc(token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			switch want := tt.want.(type) {
+			case []byte:
+				if string(got.([]byte)) != string(want) {
+					t.Errorf("key = %q, want %q", got, want)
+				}
+			case *rsa.PublicKey:
+				if !want.Equal(got) {
+					t.Error("resolved the wrong RSA key")
+				}
+			}
+		})
+	}
+}
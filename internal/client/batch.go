@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/idempotency"
+	pb "github.com/Raisondetr3/checklist-api-service/pkg/pb"
+
+	"github.com/google/uuid"
+)
+
+func (c *taskClient) BatchMutate(ctx context.Context, req *pb.BatchMutateRequest) (*pb.BatchMutateResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	if idempotency.KeyFromContext(ctx) == "" {
+		ctx = idempotency.WithKey(ctx, uuid.NewString())
+	}
+	ctx = c.addMetadata(ctx, "BatchMutate")
+
+	resp, err := c.client.BatchMutate(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("batch mutate failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *taskClient) BatchGetTasks(ctx context.Context, req *pb.BatchGetTasksRequest) (*pb.BatchGetTasksResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "BatchGetTasks")
+
+	resp, err := c.client.BatchGetTasks(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("batch get tasks failed: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/Raisondetr3/checklist-api-service/pkg/pb"
+)
+
+func (c *taskClient) ListChecklistItems(ctx context.Context, req *pb.ListChecklistItemsRequest) (*pb.ListChecklistItemsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "ListChecklistItems")
+
+	resp, err := c.client.ListChecklistItems(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("list checklist items failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *taskClient) GetChecklistItem(ctx context.Context, req *pb.GetChecklistItemRequest) (*pb.ChecklistItemResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "GetChecklistItem")
+
+	resp, err := c.client.GetChecklistItem(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("get checklist item failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *taskClient) CreateChecklistItem(ctx context.Context, req *pb.CreateChecklistItemRequest) (*pb.ChecklistItemResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "CreateChecklistItem")
+
+	resp, err := c.client.CreateChecklistItem(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("create checklist item failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *taskClient) UpdateChecklistItem(ctx context.Context, req *pb.UpdateChecklistItemRequest) (*pb.ChecklistItemResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "UpdateChecklistItem")
+
+	resp, err := c.client.UpdateChecklistItem(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("update checklist item failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *taskClient) DeleteChecklistItem(ctx context.Context, req *pb.DeleteChecklistItemRequest) (*pb.DeleteChecklistItemResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "DeleteChecklistItem")
+
+	resp, err := c.client.DeleteChecklistItem(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("delete checklist item failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *taskClient) ReorderChecklistItems(ctx context.Context, req *pb.ReorderChecklistItemsRequest) (*pb.ListChecklistItemsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "ReorderChecklistItems")
+
+	resp, err := c.client.ReorderChecklistItems(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("reorder checklist items failed: %w", err)
+	}
+
+	return resp, nil
+}
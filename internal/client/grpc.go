@@ -6,9 +6,13 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/Raisondetr3/checklist-api-service/internal/auth"
+	"github.com/Raisondetr3/checklist-api-service/internal/idempotency"
+	"github.com/Raisondetr3/checklist-api-service/pkg/logger"
 	pb "github.com/Raisondetr3/checklist-api-service/pkg/pb"
 
 	"github.com/Raisondetr3/checklist-api-service/internal/config"
+	"github.com/google/uuid"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
@@ -20,12 +24,35 @@ type TaskClient interface {
 	GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb.TaskResponse, error)
 	UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb.TaskResponse, error)
 	DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*pb.DeleteTaskResponse, error)
+	RestoreTask(ctx context.Context, req *pb.RestoreTaskRequest) (*pb.TaskResponse, error)
+	PurgeDeletedTasks(ctx context.Context, req *pb.PurgeDeletedTasksRequest) (*pb.PurgeDeletedTasksResponse, error)
 	ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error)
+	ListTags(ctx context.Context, req *pb.ListTagsRequest) (*pb.ListTagsResponse, error)
+	BatchMutate(ctx context.Context, req *pb.BatchMutateRequest) (*pb.BatchMutateResponse, error)
+	BatchGetTasks(ctx context.Context, req *pb.BatchGetTasksRequest) (*pb.BatchGetTasksResponse, error)
+	ListChecklistItems(ctx context.Context, req *pb.ListChecklistItemsRequest) (*pb.ListChecklistItemsResponse, error)
+	GetChecklistItem(ctx context.Context, req *pb.GetChecklistItemRequest) (*pb.ChecklistItemResponse, error)
+	CreateChecklistItem(ctx context.Context, req *pb.CreateChecklistItemRequest) (*pb.ChecklistItemResponse, error)
+	UpdateChecklistItem(ctx context.Context, req *pb.UpdateChecklistItemRequest) (*pb.ChecklistItemResponse, error)
+	DeleteChecklistItem(ctx context.Context, req *pb.DeleteChecklistItemRequest) (*pb.DeleteChecklistItemResponse, error)
+	ReorderChecklistItems(ctx context.Context, req *pb.ReorderChecklistItemsRequest) (*pb.ListChecklistItemsResponse, error)
+	ListShares(ctx context.Context, req *pb.ListSharesRequest) (*pb.ListSharesResponse, error)
+	ShareTask(ctx context.Context, req *pb.ShareTaskRequest) (*pb.ShareResponse, error)
+	UnshareTask(ctx context.Context, req *pb.UnshareTaskRequest) (*pb.UnshareTaskResponse, error)
+	CreateList(ctx context.Context, req *pb.CreateListRequest) (*pb.ListResponse, error)
+	GetList(ctx context.Context, req *pb.GetListRequest) (*pb.ListResponse, error)
+	UpdateList(ctx context.Context, req *pb.UpdateListRequest) (*pb.ListResponse, error)
+	DeleteList(ctx context.Context, req *pb.DeleteListRequest) (*pb.DeleteListResponse, error)
+	ListLists(ctx context.Context, req *pb.ListListsRequest) (*pb.ListListsResponse, error)
+	ListListShares(ctx context.Context, req *pb.ListListSharesRequest) (*pb.ListSharesResponse, error)
+	ShareList(ctx context.Context, req *pb.ShareListRequest) (*pb.ShareResponse, error)
+	UnshareList(ctx context.Context, req *pb.UnshareListRequest) (*pb.UnshareListResponse, error)
 	Close() error
 }
 
 type taskClient struct {
 	client pb.TaskServiceClient
+	lists  pb.ListServiceClient
 	conn   *grpc.ClientConn
 	config config.DBServiceConfig
 }
@@ -58,6 +85,7 @@ func NewTaskClient(dbConfig config.DBServiceConfig) (TaskClient, error) {
 
 	return &taskClient{
 		client: client,
+		lists:  pb.NewListServiceClient(conn),
 		conn:   conn,
 		config: dbConfig,
 	}, nil
@@ -67,6 +95,11 @@ func (c *taskClient) CreateTask(ctx context.Context, req *pb.CreateTaskRequest)
 	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
 	defer cancel()
 
+	// Retries issued by the interceptor must reuse the same key so db-service
+	// can recognise them as duplicates.
+	if idempotency.KeyFromContext(ctx) == "" {
+		ctx = idempotency.WithKey(ctx, uuid.NewString())
+	}
 	ctx = c.addMetadata(ctx, "CreateTask")
 
 	resp, err := c.client.CreateTask(ctx, req)
@@ -133,6 +166,20 @@ func (c *taskClient) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*
 	return resp, nil
 }
 
+func (c *taskClient) ListTags(ctx context.Context, req *pb.ListTagsRequest) (*pb.ListTagsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "ListTags")
+
+	resp, err := c.client.ListTags(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("list tags failed: %w", err)
+	}
+
+	return resp, nil
+}
+
 func (c *taskClient) Close() error {
 	slog.Info("Closing gRPC client connection")
 	return c.conn.Close()
@@ -145,8 +192,15 @@ func (c *taskClient) addMetadata(ctx context.Context, method string) context.Con
 		"timestamp": time.Now().Format(time.RFC3339),
 	})
 
-	if requestID := ctx.Value("request_id"); requestID != nil {
-		md.Set("request-id", requestID.(string))
+	if requestID := logger.RequestIDFromContext(ctx); requestID != "" {
+		md.Set("request-id", requestID)
+	}
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		md.Set("user-id", principal.Subject)
+		md.Set("auth-method", principal.Method)
+	}
+	if key := idempotency.KeyFromContext(ctx); key != "" {
+		md.Set("idempotency-key", key)
 	}
 
 	return metadata.NewOutgoingContext(ctx, md)
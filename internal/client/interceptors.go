@@ -7,9 +7,19 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
+// nonIdempotentMethods create new records on every call. A retry of one of
+// them is only safe when db-service can deduplicate it by idempotency key.
+var nonIdempotentMethods = map[string]bool{
+	"/task.TaskService/CreateTask":          true,
+	"/task.TaskService/BatchMutate":         true,
+	"/task.TaskService/CreateChecklistItem": true,
+	"/task.ListService/CreateList":          true,
+}
+
 func loggingUnaryInterceptor(
 	ctx context.Context,
 	method string,
@@ -104,7 +114,7 @@ func retryUnaryInterceptor(maxRetries int, retryDelay time.Duration) grpc.UnaryC
 
 			lastErr = err
 
-			if !shouldRetry(err) {
+			if !shouldRetry(err) || !retrySafe(ctx, method) {
 				break
 			}
 
@@ -145,6 +155,18 @@ func shouldRetry(err error) bool {
 	}
 }
 
+// retrySafe reports whether method may be sent again after a failure that
+// might have been applied: idempotent RPCs always, the rest only when the
+// outgoing metadata carries an idempotency key.
+func retrySafe(ctx context.Context, method string) bool {
+	if !nonIdempotentMethods[method] {
+		return true
+	}
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	return ok && len(md.Get("idempotency-key")) > 0
+}
+
 func timeoutUnaryInterceptor(defaultTimeout time.Duration) grpc.UnaryClientInterceptor {
 	return func(
 		ctx context.Context,
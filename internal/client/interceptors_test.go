@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryUnaryInterceptor(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		key       string
+		err       error
+		wantCalls int
+	}{
+		{name: "idempotent read is retried", method: "/task.TaskService/GetTask", err: status.Error(codes.Unavailable, "down"), wantCalls: 3},
+		{name: "create without key is not retried", method: "/task.TaskService/CreateTask", err: status.Error(codes.Unavailable, "down"), wantCalls: 1},
+		{name: "create with key is retried", method: "/task.TaskService/CreateTask", key: "k", err: status.Error(codes.Unavailable, "down"), wantCalls: 3},
+		{name: "batch without key is not retried", method: "/task.TaskService/BatchMutate", err: status.Error(codes.Internal, "boom"), wantCalls: 1},
+		{name: "non-retryable code", method: "/task.TaskService/GetTask", err: status.Error(codes.NotFound, "missing"), wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.key != "" {
+				ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("idempotency-key", tt.key))
+			}
+
+			calls := 0
+			invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				calls++
+				return tt.err
+			}
+
+			interceptor := retryUnaryInterceptor(2, 0)
+			if err := interceptor(ctx, tt.method, nil, nil, nil, invoker); err == nil {
+				t.Fatal("expected an error")
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("invoked %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
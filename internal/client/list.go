@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/Raisondetr3/checklist-api-service/pkg/pb"
+)
+
+func (c *taskClient) CreateList(ctx context.Context, req *pb.CreateListRequest) (*pb.ListResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "CreateList")
+
+	resp, err := c.lists.CreateList(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("create list failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *taskClient) GetList(ctx context.Context, req *pb.GetListRequest) (*pb.ListResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "GetList")
+
+	resp, err := c.lists.GetList(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("get list failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *taskClient) UpdateList(ctx context.Context, req *pb.UpdateListRequest) (*pb.ListResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "UpdateList")
+
+	resp, err := c.lists.UpdateList(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("update list failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *taskClient) DeleteList(ctx context.Context, req *pb.DeleteListRequest) (*pb.DeleteListResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "DeleteList")
+
+	resp, err := c.lists.DeleteList(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("delete list failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *taskClient) ListLists(ctx context.Context, req *pb.ListListsRequest) (*pb.ListListsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "ListLists")
+
+	resp, err := c.lists.ListLists(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("list lists failed: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/Raisondetr3/checklist-api-service/pkg/pb"
+)
+
+func (c *taskClient) ListShares(ctx context.Context, req *pb.ListSharesRequest) (*pb.ListSharesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "ListShares")
+
+	resp, err := c.client.ListShares(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("list shares failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *taskClient) ShareTask(ctx context.Context, req *pb.ShareTaskRequest) (*pb.ShareResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "ShareTask")
+
+	resp, err := c.client.ShareTask(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("share task failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *taskClient) UnshareTask(ctx context.Context, req *pb.UnshareTaskRequest) (*pb.UnshareTaskResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "UnshareTask")
+
+	resp, err := c.client.UnshareTask(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("unshare task failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *taskClient) ListListShares(ctx context.Context, req *pb.ListListSharesRequest) (*pb.ListSharesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "ListListShares")
+
+	resp, err := c.lists.ListListShares(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("list list shares failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *taskClient) ShareList(ctx context.Context, req *pb.ShareListRequest) (*pb.ShareResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "ShareList")
+
+	resp, err := c.lists.ShareList(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("share list failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *taskClient) UnshareList(ctx context.Context, req *pb.UnshareListRequest) (*pb.UnshareListResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "UnshareList")
+
+	resp, err := c.lists.UnshareList(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("unshare list failed: %w", err)
+	}
+
+	return resp, nil
+}
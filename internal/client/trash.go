@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/Raisondetr3/checklist-api-service/pkg/pb"
+)
+
+func (c *taskClient) RestoreTask(ctx context.Context, req *pb.RestoreTaskRequest) (*pb.TaskResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "RestoreTask")
+
+	resp, err := c.client.RestoreTask(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("restore task failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *taskClient) PurgeDeletedTasks(ctx context.Context, req *pb.PurgeDeletedTasksRequest) (*pb.PurgeDeletedTasksResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	ctx = c.addMetadata(ctx, "PurgeDeletedTasks")
+
+	resp, err := c.client.PurgeDeletedTasks(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("purge deleted tasks failed: %w", err)
+	}
+
+	return resp, nil
+}
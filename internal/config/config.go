@@ -3,12 +3,19 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 type Config struct {
 	Server           ServerConfig
 	Logging          LoggingConfig
+	Auth             AuthConfig
+	RateLimit        RateLimitConfig
+	Idempotency      IdempotencyConfig
+	Trash            TrashConfig
+	History          HistoryConfig
+	Export           ExportConfig
 	ExternalServices ExternalServicesConfig
 }
 
@@ -26,6 +33,66 @@ type LoggingConfig struct {
 	Format   string
 }
 
+type AuthConfig struct {
+	Enabled bool
+	JWT     JWTConfig
+	APIKeys APIKeyConfig
+}
+
+type JWTConfig struct {
+	HMACSecret       string
+	RSAPublicKeyFile string
+	JWKSFile         string
+	Issuer           string
+	Audience         string
+	Leeway           time.Duration
+}
+
+// APIKeyConfig locates the API key store. BootstrapAdminKey, when set, is a
+// plaintext ck_ key added to the store with the admin scope at startup, so a
+// fresh deployment has a credential to mint further keys with.
+type APIKeyConfig struct {
+	StoreFile         string
+	BootstrapAdminKey string
+}
+
+// RateLimitConfig sets the per-client Read and Write limits applied after
+// authentication and the per-address IP limit applied before it.
+type RateLimitConfig struct {
+	Enabled bool
+	Read    RateLimitRule
+	Write   RateLimitRule
+	IP      RateLimitRule
+	IdleTTL time.Duration
+}
+
+type RateLimitRule struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+type IdempotencyConfig struct {
+	TTL        time.Duration
+	MaxEntries int
+}
+
+type TrashConfig struct {
+	PurgeEnabled  bool
+	Retention     time.Duration
+	PurgeInterval time.Duration
+}
+
+type HistoryConfig struct {
+	Store               string
+	File                string
+	MaxRevisionsPerTask int
+	PurgedRetention     time.Duration
+}
+
+type ExportConfig struct {
+	MaxRows int
+}
+
 type ExternalServicesConfig struct {
 	DBService DBServiceConfig
 	Kafka     KafkaConfig
@@ -39,12 +106,51 @@ type DBServiceConfig struct {
 	RetryDelay       time.Duration
 	KeepAliveTime    time.Duration
 	KeepAliveTimeout time.Duration
+	Capabilities     DBServiceCapabilities
+}
+
+type DBServiceCapabilities struct {
+	ListFilters bool
+	Search      bool
+
+	// MaxInMemoryTasks caps the collection size the service will load to
+	// filter, search and sort itself when db-service cannot.
+	MaxInMemoryTasks int
 }
 
 type KafkaConfig struct {
+	Enabled bool
 	Brokers []string
 	Topic   string
 	Timeout time.Duration
+	TLS     KafkaTLSConfig
+	SASL    KafkaSASLConfig
+	Outbox  OutboxConfig
+}
+
+type KafkaTLSConfig struct {
+	Enabled            bool
+	CAFile             string
+	InsecureSkipVerify bool
+}
+
+// KafkaSASLConfig selects SASL authentication; Mechanism is one of plain,
+// scram-sha-256 or scram-sha-512, or empty to disable it.
+type KafkaSASLConfig struct {
+	Mechanism string
+	Username  string
+	Password  string
+}
+
+// OutboxConfig tunes the on-disk event outbox. CompactEvery is the number
+// of acknowledged events after which the log is rewritten; CloseTimeout
+// bounds how long shutdown waits for an in-flight delivery.
+type OutboxConfig struct {
+	Dir          string
+	MinBackoff   time.Duration
+	MaxBackoff   time.Duration
+	CompactEvery int
+	CloseTimeout time.Duration
 }
 
 func Load() (*Config, error) {
@@ -66,6 +172,34 @@ func setDefaults(cfg *Config) {
 	cfg.Logging.FileName = "api-service.log"
 	cfg.Logging.Format = "json"
 
+	// Authentication is on unless AUTH_ENABLED=false opts out explicitly.
+	cfg.Auth.Enabled = true
+	cfg.Auth.JWT.Leeway = 30 * time.Second
+	cfg.Auth.APIKeys.StoreFile = "data/api_keys.json"
+
+	cfg.RateLimit.Enabled = true
+	cfg.RateLimit.Read.RequestsPerSecond = 20
+	cfg.RateLimit.Read.Burst = 40
+	cfg.RateLimit.Write.RequestsPerSecond = 5
+	cfg.RateLimit.Write.Burst = 10
+	cfg.RateLimit.IP.RequestsPerSecond = 50
+	cfg.RateLimit.IP.Burst = 100
+	cfg.RateLimit.IdleTTL = 10 * time.Minute
+
+	cfg.Idempotency.TTL = 24 * time.Hour
+	cfg.Idempotency.MaxEntries = 10000
+
+	cfg.Trash.PurgeEnabled = true
+	cfg.Trash.Retention = 30 * 24 * time.Hour
+	cfg.Trash.PurgeInterval = time.Hour
+
+	cfg.History.Store = "memory"
+	cfg.History.File = "data/history.jsonl"
+	cfg.History.MaxRevisionsPerTask = 500
+	cfg.History.PurgedRetention = 30 * 24 * time.Hour
+
+	cfg.Export.MaxRows = 10000
+
 	cfg.ExternalServices.DBService.HTTPUrl = "http://localhost:8081"
 	cfg.ExternalServices.DBService.GRPCAddress = "localhost:9090"
 	cfg.ExternalServices.DBService.Timeout = 30 * time.Second
@@ -73,10 +207,18 @@ func setDefaults(cfg *Config) {
 	cfg.ExternalServices.DBService.RetryDelay = 1 * time.Second
 	cfg.ExternalServices.DBService.KeepAliveTime = 30 * time.Second
 	cfg.ExternalServices.DBService.KeepAliveTimeout = 5 * time.Second
+	cfg.ExternalServices.DBService.Capabilities.ListFilters = true
+	cfg.ExternalServices.DBService.Capabilities.Search = true
+	cfg.ExternalServices.DBService.Capabilities.MaxInMemoryTasks = 1000
 
 	cfg.ExternalServices.Kafka.Brokers = []string{"localhost:9092"}
 	cfg.ExternalServices.Kafka.Topic = "checklist-events"
 	cfg.ExternalServices.Kafka.Timeout = 10 * time.Second
+	cfg.ExternalServices.Kafka.Outbox.Dir = "data/outbox"
+	cfg.ExternalServices.Kafka.Outbox.MinBackoff = 500 * time.Millisecond
+	cfg.ExternalServices.Kafka.Outbox.MaxBackoff = 30 * time.Second
+	cfg.ExternalServices.Kafka.Outbox.CompactEvery = 1000
+	cfg.ExternalServices.Kafka.Outbox.CloseTimeout = 5 * time.Second
 }
 
 func overrideFromEnv(cfg *Config) {
@@ -106,6 +248,93 @@ func overrideFromEnv(cfg *Config) {
 		cfg.Logging.Format = format
 	}
 
+	if enabled, ok := parseBoolFromEnv("AUTH_ENABLED"); ok {
+		cfg.Auth.Enabled = enabled
+	}
+	if secret := os.Getenv("JWT_HMAC_SECRET"); secret != "" {
+		cfg.Auth.JWT.HMACSecret = secret
+	}
+	if keyFile := os.Getenv("JWT_RSA_PUBLIC_KEY_FILE"); keyFile != "" {
+		cfg.Auth.JWT.RSAPublicKeyFile = keyFile
+	}
+	if jwksFile := os.Getenv("JWT_JWKS_FILE"); jwksFile != "" {
+		cfg.Auth.JWT.JWKSFile = jwksFile
+	}
+	if issuer := os.Getenv("JWT_ISSUER"); issuer != "" {
+		cfg.Auth.JWT.Issuer = issuer
+	}
+	if audience := os.Getenv("JWT_AUDIENCE"); audience != "" {
+		cfg.Auth.JWT.Audience = audience
+	}
+	if leeway := parseDurationFromEnv("JWT_LEEWAY"); leeway > 0 {
+		cfg.Auth.JWT.Leeway = leeway
+	}
+	if storeFile := os.Getenv("API_KEYS_FILE"); storeFile != "" {
+		cfg.Auth.APIKeys.StoreFile = storeFile
+	}
+	if bootstrapKey := os.Getenv("API_KEYS_BOOTSTRAP_ADMIN_KEY"); bootstrapKey != "" {
+		cfg.Auth.APIKeys.BootstrapAdminKey = bootstrapKey
+	}
+
+	if enabled, ok := parseBoolFromEnv("RATE_LIMIT_ENABLED"); ok {
+		cfg.RateLimit.Enabled = enabled
+	}
+	if rps := parseFloatFromEnv("RATE_LIMIT_READ_RPS"); rps > 0 {
+		cfg.RateLimit.Read.RequestsPerSecond = rps
+	}
+	if burst := parseIntFromEnv("RATE_LIMIT_READ_BURST"); burst > 0 {
+		cfg.RateLimit.Read.Burst = burst
+	}
+	if rps := parseFloatFromEnv("RATE_LIMIT_WRITE_RPS"); rps > 0 {
+		cfg.RateLimit.Write.RequestsPerSecond = rps
+	}
+	if burst := parseIntFromEnv("RATE_LIMIT_WRITE_BURST"); burst > 0 {
+		cfg.RateLimit.Write.Burst = burst
+	}
+	if rps := parseFloatFromEnv("RATE_LIMIT_IP_RPS"); rps > 0 {
+		cfg.RateLimit.IP.RequestsPerSecond = rps
+	}
+	if burst := parseIntFromEnv("RATE_LIMIT_IP_BURST"); burst > 0 {
+		cfg.RateLimit.IP.Burst = burst
+	}
+	if ttl := parseDurationFromEnv("RATE_LIMIT_IDLE_TTL"); ttl > 0 {
+		cfg.RateLimit.IdleTTL = ttl
+	}
+
+	if ttl := parseDurationFromEnv("IDEMPOTENCY_TTL"); ttl > 0 {
+		cfg.Idempotency.TTL = ttl
+	}
+	if maxEntries := parseIntFromEnv("IDEMPOTENCY_MAX_ENTRIES"); maxEntries > 0 {
+		cfg.Idempotency.MaxEntries = maxEntries
+	}
+
+	if enabled, ok := parseBoolFromEnv("TRASH_PURGE_ENABLED"); ok {
+		cfg.Trash.PurgeEnabled = enabled
+	}
+	if retention := parseDurationFromEnv("TRASH_RETENTION"); retention > 0 {
+		cfg.Trash.Retention = retention
+	}
+	if interval := parseDurationFromEnv("TRASH_PURGE_INTERVAL"); interval > 0 {
+		cfg.Trash.PurgeInterval = interval
+	}
+
+	if store := os.Getenv("HISTORY_STORE"); store != "" {
+		cfg.History.Store = strings.ToLower(store)
+	}
+	if file := os.Getenv("HISTORY_FILE"); file != "" {
+		cfg.History.File = file
+	}
+	if maxRevisions := parseIntFromEnv("HISTORY_MAX_REVISIONS_PER_TASK"); maxRevisions > 0 {
+		cfg.History.MaxRevisionsPerTask = maxRevisions
+	}
+	if retention := parseDurationFromEnv("HISTORY_PURGED_RETENTION"); retention > 0 {
+		cfg.History.PurgedRetention = retention
+	}
+
+	if maxRows := parseIntFromEnv("EXPORT_MAX_ROWS"); maxRows > 0 {
+		cfg.Export.MaxRows = maxRows
+	}
+
 	if httpUrl := os.Getenv("DB_SERVICE_HTTP_URL"); httpUrl != "" {
 		cfg.ExternalServices.DBService.HTTPUrl = httpUrl
 	}
@@ -127,9 +356,21 @@ func overrideFromEnv(cfg *Config) {
 	if keepAliveTimeout := parseDurationFromEnv("DB_SERVICE_KEEPALIVE_TIMEOUT"); keepAliveTimeout > 0 {
 		cfg.ExternalServices.DBService.KeepAliveTimeout = keepAliveTimeout
 	}
+	if listFilters, ok := parseBoolFromEnv("DB_SERVICE_SUPPORTS_LIST_FILTERS"); ok {
+		cfg.ExternalServices.DBService.Capabilities.ListFilters = listFilters
+	}
+	if search, ok := parseBoolFromEnv("DB_SERVICE_SUPPORTS_SEARCH"); ok {
+		cfg.ExternalServices.DBService.Capabilities.Search = search
+	}
+	if maxTasks := parseIntFromEnv("DB_SERVICE_MAX_IN_MEMORY_TASKS"); maxTasks > 0 {
+		cfg.ExternalServices.DBService.Capabilities.MaxInMemoryTasks = maxTasks
+	}
 
+	if enabled, ok := parseBoolFromEnv("KAFKA_ENABLED"); ok {
+		cfg.ExternalServices.Kafka.Enabled = enabled
+	}
 	if kafkaBrokers := os.Getenv("KAFKA_BROKERS"); kafkaBrokers != "" {
-		cfg.ExternalServices.Kafka.Brokers = []string{kafkaBrokers}
+		cfg.ExternalServices.Kafka.Brokers = strings.Split(kafkaBrokers, ",")
 	}
 	if kafkaTopic := os.Getenv("KAFKA_TOPIC"); kafkaTopic != "" {
 		cfg.ExternalServices.Kafka.Topic = kafkaTopic
@@ -137,6 +378,39 @@ func overrideFromEnv(cfg *Config) {
 	if timeout := parseDurationFromEnv("KAFKA_TIMEOUT"); timeout > 0 {
 		cfg.ExternalServices.Kafka.Timeout = timeout
 	}
+	if enabled, ok := parseBoolFromEnv("KAFKA_TLS_ENABLED"); ok {
+		cfg.ExternalServices.Kafka.TLS.Enabled = enabled
+	}
+	if caFile := os.Getenv("KAFKA_TLS_CA_FILE"); caFile != "" {
+		cfg.ExternalServices.Kafka.TLS.CAFile = caFile
+	}
+	if skipVerify, ok := parseBoolFromEnv("KAFKA_TLS_INSECURE_SKIP_VERIFY"); ok {
+		cfg.ExternalServices.Kafka.TLS.InsecureSkipVerify = skipVerify
+	}
+	if mechanism := os.Getenv("KAFKA_SASL_MECHANISM"); mechanism != "" {
+		cfg.ExternalServices.Kafka.SASL.Mechanism = strings.ToLower(mechanism)
+	}
+	if username := os.Getenv("KAFKA_SASL_USERNAME"); username != "" {
+		cfg.ExternalServices.Kafka.SASL.Username = username
+	}
+	if password := os.Getenv("KAFKA_SASL_PASSWORD"); password != "" {
+		cfg.ExternalServices.Kafka.SASL.Password = password
+	}
+	if outboxDir := os.Getenv("OUTBOX_DIR"); outboxDir != "" {
+		cfg.ExternalServices.Kafka.Outbox.Dir = outboxDir
+	}
+	if backoff := parseDurationFromEnv("OUTBOX_MIN_BACKOFF"); backoff > 0 {
+		cfg.ExternalServices.Kafka.Outbox.MinBackoff = backoff
+	}
+	if backoff := parseDurationFromEnv("OUTBOX_MAX_BACKOFF"); backoff > 0 {
+		cfg.ExternalServices.Kafka.Outbox.MaxBackoff = backoff
+	}
+	if every := parseIntFromEnv("OUTBOX_COMPACT_EVERY"); every > 0 {
+		cfg.ExternalServices.Kafka.Outbox.CompactEvery = every
+	}
+	if timeout := parseDurationFromEnv("OUTBOX_CLOSE_TIMEOUT"); timeout > 0 {
+		cfg.ExternalServices.Kafka.Outbox.CloseTimeout = timeout
+	}
 }
 
 func parseDurationFromEnv(key string) time.Duration {
@@ -155,4 +429,22 @@ func parseIntFromEnv(key string) int {
 		}
 	}
 	return 0
+}
+
+func parseFloatFromEnv(key string) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return 0
+}
+
+func parseBoolFromEnv(key string) (bool, bool) {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue, true
+		}
+	}
+	return false, false
 }
\ No newline at end of file
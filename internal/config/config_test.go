@@ -0,0 +1,57 @@
+package config
+
+import "testing"
+
+func TestLoadAuthEnabled(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		want bool
+	}{
+		{name: "default", env: "", want: true},
+		{name: "explicit opt-out", env: "false", want: false},
+		{name: "explicit opt-in", env: "true", want: true},
+		{name: "unparsable keeps default", env: "maybe", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AUTH_ENABLED", tt.env)
+
+			cfg, err := Load()
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if cfg.Auth.Enabled != tt.want {
+				t.Errorf("Auth.Enabled = %v, want %v", cfg.Auth.Enabled, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadMaxInMemoryTasks(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		want int
+	}{
+		{name: "default", env: "", want: 1000},
+		{name: "configured", env: "5000", want: 5000},
+		{name: "non-positive keeps default", env: "0", want: 1000},
+		{name: "unparsable keeps default", env: "many", want: 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DB_SERVICE_MAX_IN_MEMORY_TASKS", tt.env)
+
+			cfg, err := Load()
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if got := cfg.ExternalServices.DBService.Capabilities.MaxInMemoryTasks; got != tt.want {
+				t.Errorf("MaxInMemoryTasks = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
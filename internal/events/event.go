@@ -0,0 +1,56 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+
+	"github.com/google/uuid"
+)
+
+type Event struct {
+	ID        string            `json:"id"`
+	Type      string            `json:"type"`
+	TaskID    string            `json:"task_id"`
+	RequestID string            `json:"request_id,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+	Task      *dto.TaskResponse `json:"task,omitempty"`
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+	Close() error
+}
+
+func NewTaskEvent(eventType string, task *model.Task, requestID string) Event {
+	event := Event{
+		ID:        uuid.New().String(),
+		Type:      eventType,
+		RequestID: requestID,
+		Timestamp: time.Now().UTC(),
+	}
+
+	if task != nil {
+		snapshot := dto.TaskModelToResponse(task)
+		event.TaskID = task.ID
+		event.Task = &snapshot
+	}
+
+	return event
+}
+
+type nopPublisher struct{}
+
+func NewNopPublisher() Publisher {
+	return nopPublisher{}
+}
+
+func (nopPublisher) Publish(ctx context.Context, event Event) error {
+	return nil
+}
+
+func (nopPublisher) Close() error {
+	return nil
+}
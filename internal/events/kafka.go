@@ -0,0 +1,166 @@
+package events
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/config"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+const (
+	kafkaClientID = "checklist-api-service"
+
+	// The outbox hands over one event at a time, so there is nothing to
+	// gain from waiting for a batch to fill up.
+	kafkaBatchTimeout = 5 * time.Millisecond
+)
+
+// KafkaPublisher writes events through kafka-go, which takes care of leader
+// discovery, retries on leadership changes and concurrent writes to
+// different partitions. Events are keyed by task ID so all events for a
+// task land on the same partition in order.
+type KafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaPublisher(cfg config.KafkaConfig) (*KafkaPublisher, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.New("kafka: at least one broker is required")
+	}
+	if cfg.Topic == "" {
+		return nil, errors.New("kafka: topic is required")
+	}
+
+	transport, err := newKafkaTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(cfg.Brokers...),
+		Topic:        cfg.Topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		BatchTimeout: kafkaBatchTimeout,
+		WriteTimeout: cfg.Timeout,
+		ReadTimeout:  cfg.Timeout,
+		Transport:    transport,
+	}
+
+	slog.Info("Kafka publisher configured",
+		slog.Any("brokers", cfg.Brokers),
+		slog.String("topic", cfg.Topic),
+		slog.Duration("timeout", cfg.Timeout),
+		slog.Bool("tls", cfg.TLS.Enabled),
+		slog.String("sasl_mechanism", cfg.SASL.Mechanism),
+	)
+
+	return &KafkaPublisher{writer: writer}, nil
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, event Event) error {
+	message, err := newKafkaMessage(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.writer.WriteMessages(ctx, message); err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", event.Type, err)
+	}
+
+	return nil
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
+
+func newKafkaMessage(event Event) (kafka.Message, error) {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return kafka.Message{}, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	return kafka.Message{
+		Key:   []byte(event.TaskID),
+		Value: value,
+		Headers: []kafka.Header{
+			{Key: "event-type", Value: []byte(event.Type)},
+			{Key: "request-id", Value: []byte(event.RequestID)},
+		},
+		Time: event.Timestamp,
+	}, nil
+}
+
+func newKafkaTransport(cfg config.KafkaConfig) (*kafka.Transport, error) {
+	transport := &kafka.Transport{
+		ClientID:    kafkaClientID,
+		DialTimeout: cfg.Timeout,
+	}
+
+	if cfg.TLS.Enabled {
+		tlsConfig, err := newKafkaTLSConfig(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLS = tlsConfig
+	}
+
+	mechanism, err := newSASLMechanism(cfg.SASL)
+	if err != nil {
+		return nil, err
+	}
+	transport.SASL = mechanism
+
+	return transport, nil
+}
+
+func newKafkaTLSConfig(cfg config.KafkaTLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("kafka: failed to read CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("kafka: no certificates found in %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+func newSASLMechanism(cfg config.KafkaSASLConfig) (sasl.Mechanism, error) {
+	switch strings.ToLower(cfg.Mechanism) {
+	case "":
+		return nil, nil
+	case "plain":
+		return plain.Mechanism{Username: cfg.Username, Password: cfg.Password}, nil
+	case "scram-sha-256":
+		return scram.Mechanism(scram.SHA256, cfg.Username, cfg.Password)
+	case "scram-sha-512":
+		return scram.Mechanism(scram.SHA512, cfg.Username, cfg.Password)
+	default:
+		return nil, fmt.Errorf("kafka: unsupported SASL mechanism %q", cfg.Mechanism)
+	}
+}
@@ -0,0 +1,137 @@
+package events
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/config"
+)
+
+func TestNewKafkaMessage(t *testing.T) {
+	event := Event{
+		ID:        "evt-1",
+		Type:      "task.created",
+		TaskID:    "task-1",
+		RequestID: "req-1",
+		Timestamp: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	message, err := newKafkaMessage(event)
+	if err != nil {
+		t.Fatalf("newKafkaMessage: %v", err)
+	}
+
+	if string(message.Key) != "task-1" {
+		t.Errorf("key = %q, want task-1", message.Key)
+	}
+	if !message.Time.Equal(event.Timestamp) {
+		t.Errorf("time = %v, want %v", message.Time, event.Timestamp)
+	}
+
+	headers := map[string]string{}
+	for _, header := range message.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	if headers["event-type"] != "task.created" || headers["request-id"] != "req-1" {
+		t.Errorf("headers = %v", headers)
+	}
+
+	var decoded Event
+	if err := json.Unmarshal(message.Value, &decoded); err != nil {
+		t.Fatalf("value is not an event: %v", err)
+	}
+	if decoded.ID != event.ID || decoded.Type != event.Type || decoded.TaskID != event.TaskID {
+		t.Errorf("decoded = %+v, want %+v", decoded, event)
+	}
+}
+
+func TestNewKafkaPublisherValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.KafkaConfig
+		wantErr bool
+	}{
+		{name: "valid", cfg: config.KafkaConfig{Brokers: []string{"localhost:9092"}, Topic: "events"}},
+		{name: "no brokers", cfg: config.KafkaConfig{Topic: "events"}, wantErr: true},
+		{name: "no topic", cfg: config.KafkaConfig{Brokers: []string{"localhost:9092"}}, wantErr: true},
+		{
+			name: "unknown sasl mechanism",
+			cfg: config.KafkaConfig{
+				Brokers: []string{"localhost:9092"},
+				Topic:   "events",
+				SASL:    config.KafkaSASLConfig{Mechanism: "gssapi"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing ca file",
+			cfg: config.KafkaConfig{
+				Brokers: []string{"localhost:9092"},
+				Topic:   "events",
+				TLS:     config.KafkaTLSConfig{Enabled: true, CAFile: filepath.Join(t.TempDir(), "missing.pem")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher, err := NewKafkaPublisher(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if publisher != nil {
+				publisher.Close()
+			}
+		})
+	}
+}
+
+func TestNewSASLMechanism(t *testing.T) {
+	tests := []struct {
+		mechanism string
+		wantName  string
+		wantErr   bool
+	}{
+		{mechanism: "", wantName: ""},
+		{mechanism: "plain", wantName: "PLAIN"},
+		{mechanism: "PLAIN", wantName: "PLAIN"},
+		{mechanism: "scram-sha-256", wantName: "SCRAM-SHA-256"},
+		{mechanism: "scram-sha-512", wantName: "SCRAM-SHA-512"},
+		{mechanism: "oauthbearer", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mechanism, func(t *testing.T) {
+			mechanism, err := newSASLMechanism(config.KafkaSASLConfig{Mechanism: tt.mechanism, Username: "u", Password: "p"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			name := ""
+			if mechanism != nil {
+				name = mechanism.Name()
+			}
+			if name != tt.wantName {
+				t.Errorf("mechanism = %q, want %q", name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestNewKafkaTLSConfigRejectsEmptyCAFile(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte("not a certificate"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := newKafkaTLSConfig(config.KafkaTLSConfig{Enabled: true, CAFile: caFile}); err == nil {
+		t.Fatal("expected an error for a CA file without certificates")
+	}
+}
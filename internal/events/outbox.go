@@ -0,0 +1,476 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/config"
+)
+
+const (
+	outboxLogFile    = "outbox.jsonl"
+	outboxOffsetFile = "outbox.offset"
+
+	defaultCompactEvery = 1000
+	defaultCloseTimeout = 5 * time.Second
+)
+
+// Outbox persists events to disk before handing them to the downstream
+// publisher, so events survive broker outages and restarts. Events are
+// delivered in order by a single worker; delivery is at-least-once.
+//
+// The offset file names the current log generation and how many of its
+// events were acknowledged. Compaction writes a new generation and then
+// swaps the offset file, so the log and offset always change together.
+// Compaction runs once compactEvery events were acknowledged, so a drained
+// queue under light load only rewrites the offset file.
+type Outbox struct {
+	publisher    Publisher
+	dir          string
+	minBackoff   time.Duration
+	maxBackoff   time.Duration
+	compactEvery int
+	closeTimeout time.Duration
+
+	mu      sync.Mutex
+	log     *os.File
+	gen     int
+	pending []Event
+	acked   int
+
+	// ctx bounds in-flight deliveries; Close cancels it so a publisher
+	// retrying against an unreachable broker returns promptly.
+	ctx     context.Context
+	cancel  context.CancelFunc
+	notify  chan struct{}
+	stop    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+}
+
+func NewOutbox(cfg config.OutboxConfig, publisher Publisher) (*Outbox, error) {
+	if err := os.MkdirAll(cfg.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create outbox directory: %w", err)
+	}
+
+	compactEvery := cfg.CompactEvery
+	if compactEvery <= 0 {
+		compactEvery = defaultCompactEvery
+	}
+	closeTimeout := cfg.CloseTimeout
+	if closeTimeout <= 0 {
+		closeTimeout = defaultCloseTimeout
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	o := &Outbox{
+		publisher:    publisher,
+		dir:          cfg.Dir,
+		minBackoff:   cfg.MinBackoff,
+		maxBackoff:   cfg.MaxBackoff,
+		compactEvery: compactEvery,
+		closeTimeout: closeTimeout,
+		ctx:          ctx,
+		cancel:       cancel,
+		notify:       make(chan struct{}, 1),
+		stop:         make(chan struct{}),
+		stopped:      make(chan struct{}),
+	}
+
+	if err := o.load(); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	slog.Info("Event outbox opened",
+		slog.String("dir", cfg.Dir),
+		slog.Int("pending", len(o.pending)),
+	)
+
+	go o.run()
+	o.wake()
+
+	return o, nil
+}
+
+func (o *Outbox) Publish(ctx context.Context, event Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	line = append(line, '\n')
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	info, err := o.log.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat outbox log: %w", err)
+	}
+
+	// Acks count log lines, so a line that is not also queued in pending
+	// would shift every later offset. A failed write or sync is cut back
+	// out of the log before the error is returned.
+	if _, err = o.log.Write(line); err != nil {
+		err = fmt.Errorf("failed to write event to outbox: %w", err)
+	} else if err = o.log.Sync(); err != nil {
+		err = fmt.Errorf("failed to sync outbox: %w", err)
+	}
+	if err != nil {
+		if truncErr := o.log.Truncate(info.Size()); truncErr != nil {
+			return errors.Join(err, fmt.Errorf("failed to roll back outbox log: %w", truncErr))
+		}
+		return err
+	}
+
+	o.pending = append(o.pending, event)
+	o.wake()
+
+	return nil
+}
+
+func (o *Outbox) Depth() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return len(o.pending)
+}
+
+// Flush blocks until every pending event has been delivered or ctx is done.
+func (o *Outbox) Flush(ctx context.Context) error {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		depth := o.Depth()
+		if depth == 0 {
+			return nil
+		}
+
+		o.wake()
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("outbox flush interrupted with %d pending events: %w", depth, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// Close stops the delivery worker, cancelling any in-flight publish, and
+// waits at most closeTimeout for it to exit. Undelivered events stay in the
+// log and are replayed on the next start.
+func (o *Outbox) Close() error {
+	o.once.Do(func() {
+		close(o.stop)
+		o.cancel()
+	})
+
+	timer := time.NewTimer(o.closeTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-o.stopped:
+	case <-timer.C:
+		return errors.Join(
+			fmt.Errorf("outbox worker did not stop within %s", o.closeTimeout),
+			o.publisher.Close(),
+		)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return errors.Join(o.log.Close(), o.publisher.Close())
+}
+
+func (o *Outbox) wake() {
+	select {
+	case o.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (o *Outbox) run() {
+	defer close(o.stopped)
+
+	backoff := o.minBackoff
+
+	for {
+		event, ok := o.head()
+		if !ok {
+			select {
+			case <-o.notify:
+				continue
+			case <-o.stop:
+				return
+			}
+		}
+
+		if err := o.publisher.Publish(o.ctx, event); err != nil {
+			if o.ctx.Err() != nil {
+				return
+			}
+
+			slog.Warn("Failed to deliver outbox event, will retry",
+				slog.String("event_id", event.ID),
+				slog.String("event_type", event.Type),
+				slog.Duration("backoff", backoff),
+				slog.Int("pending", o.Depth()),
+				slog.String("error", err.Error()),
+			)
+
+			// New events must not cut the wait short, or an outage
+			// would be retried at the request rate.
+			select {
+			case <-time.After(jitter(backoff)):
+			case <-o.stop:
+				return
+			}
+
+			backoff = min(backoff*2, o.maxBackoff)
+			continue
+		}
+
+		backoff = o.minBackoff
+		if err := o.ack(); err != nil {
+			slog.Error("Failed to record outbox progress", slog.String("error", err.Error()))
+		}
+	}
+}
+
+func (o *Outbox) head() (Event, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if len(o.pending) == 0 {
+		return Event{}, false
+	}
+	return o.pending[0], true
+}
+
+func (o *Outbox) ack() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.pending = o.pending[1:]
+	o.acked++
+
+	if o.acked >= o.compactEvery {
+		err := o.compact()
+		if err == nil {
+			return nil
+		}
+		slog.Warn("Failed to compact outbox log", slog.String("error", err.Error()))
+	}
+
+	return o.writeOffset(o.gen, o.acked)
+}
+
+func (o *Outbox) load() error {
+	gen, acked := 0, 0
+	if data, err := os.ReadFile(o.path(outboxOffsetFile)); err == nil {
+		gen, acked = parseOffset(string(data))
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read outbox offset: %w", err)
+	}
+	o.gen = gen
+
+	if err := o.readLog(logName(gen), acked); err != nil {
+		return err
+	}
+
+	return o.compact()
+}
+
+func (o *Outbox) readLog(name string, acked int) error {
+	logFile, err := os.Open(o.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open outbox log: %w", err)
+	}
+	defer logFile.Close()
+
+	scanner := bufio.NewScanner(logFile)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		if line <= acked {
+			continue
+		}
+
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			slog.Warn("Skipping corrupt outbox entry",
+				slog.Int("line", line),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		o.pending = append(o.pending, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read outbox log: %w", err)
+	}
+
+	return nil
+}
+
+// compact writes the pending events to a new log generation and commits it
+// by replacing the offset file. A crash before the commit leaves the old
+// generation and offset in place; a crash after it leaves the new ones.
+func (o *Outbox) compact() error {
+	var data []byte
+	for _, event := range o.pending {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal pending event: %w", err)
+		}
+		data = append(data, line...)
+		data = append(data, '\n')
+	}
+
+	gen := o.gen + 1
+	name := o.path(logName(gen))
+
+	logFile, err := os.OpenFile(name, os.O_CREATE|os.O_TRUNC|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create outbox log: %w", err)
+	}
+
+	if _, err = logFile.Write(data); err == nil {
+		err = logFile.Sync()
+	}
+	if err == nil {
+		err = syncDir(o.dir)
+	}
+	if err == nil {
+		err = o.writeOffset(gen, 0)
+	}
+	if err != nil {
+		logFile.Close()
+		os.Remove(name)
+		return fmt.Errorf("failed to compact outbox log: %w", err)
+	}
+
+	if o.log != nil {
+		o.log.Close()
+	}
+	o.log = logFile
+	o.gen = gen
+	o.acked = 0
+
+	o.removeStaleLogs()
+	return nil
+}
+
+func (o *Outbox) writeOffset(gen, acked int) error {
+	return writeFileAtomic(o.path(outboxOffsetFile), []byte(fmt.Sprintf("%d %d", gen, acked)))
+}
+
+// removeStaleLogs deletes log generations other than the current one, left
+// behind by earlier compactions or crashes.
+func (o *Outbox) removeStaleLogs() {
+	current := logName(o.gen)
+
+	names, _ := filepath.Glob(o.path("outbox*.jsonl"))
+	for _, name := range names {
+		if filepath.Base(name) == current {
+			continue
+		}
+		if err := os.Remove(name); err != nil {
+			slog.Warn("Failed to remove stale outbox log",
+				slog.String("file", name),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+func (o *Outbox) path(name string) string {
+	return filepath.Join(o.dir, name)
+}
+
+func logName(gen int) string {
+	if gen == 0 {
+		return outboxLogFile
+	}
+	return fmt.Sprintf("outbox.%d.jsonl", gen)
+}
+
+// parseOffset reads "<generation> <acked>". Older outboxes stored only the
+// acked count for the unversioned log, which is generation 0.
+func parseOffset(data string) (int, int) {
+	fields := strings.Fields(data)
+	switch len(fields) {
+	case 1:
+		acked, _ := strconv.Atoi(fields[0])
+		return 0, acked
+	case 2:
+		gen, _ := strconv.Atoi(fields[0])
+		acked, _ := strconv.Atoi(fields[1])
+		return gen, acked
+	default:
+		return 0, 0
+	}
+}
+
+// writeFileAtomic replaces path with data, syncing the file and its
+// directory so the rename survives a crash.
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	return syncDir(filepath.Dir(path))
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
+
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return d
+	}
+	return d/2 + rand.N(d/2+1)
+}
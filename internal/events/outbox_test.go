@@ -0,0 +1,355 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/config"
+)
+
+// scriptedPublisher accepts the first `accept` events and rejects the rest.
+type scriptedPublisher struct {
+	mu        sync.Mutex
+	accept    int
+	attempts  int
+	delivered []Event
+}
+
+func (p *scriptedPublisher) Publish(ctx context.Context, event Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.attempts++
+	if len(p.delivered) >= p.accept {
+		return errors.New("broker unavailable")
+	}
+	p.delivered = append(p.delivered, event)
+	return nil
+}
+
+func (p *scriptedPublisher) Close() error {
+	return nil
+}
+
+func (p *scriptedPublisher) ids() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ids := make([]string, len(p.delivered))
+	for i, event := range p.delivered {
+		ids[i] = event.ID
+	}
+	return ids
+}
+
+func testOutboxConfig(dir string) config.OutboxConfig {
+	return config.OutboxConfig{Dir: dir, MinBackoff: 5 * time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+}
+
+func openTestOutbox(t *testing.T, dir string, publisher Publisher) *Outbox {
+	t.Helper()
+
+	outbox, err := NewOutbox(testOutboxConfig(dir), publisher)
+	if err != nil {
+		t.Fatalf("NewOutbox: %v", err)
+	}
+	return outbox
+}
+
+func publishEvents(t *testing.T, outbox *Outbox, ids ...string) {
+	t.Helper()
+
+	for _, id := range ids {
+		if err := outbox.Publish(context.Background(), Event{ID: id, Type: "task.created", TaskID: "task-" + id}); err != nil {
+			t.Fatalf("Publish(%s): %v", id, err)
+		}
+	}
+}
+
+func waitForDepth(t *testing.T, outbox *Outbox, depth int) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for outbox.Depth() != depth {
+		if time.Now().After(deadline) {
+			t.Fatalf("depth = %d, want %d", outbox.Depth(), depth)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func flushAndClose(t *testing.T, outbox *Outbox) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := outbox.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if err := outbox.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func TestOutboxReplaysAfterRestart(t *testing.T) {
+	tests := []struct {
+		name     string
+		accepted int
+		want     []string
+	}{
+		{name: "nothing delivered", accepted: 0, want: []string{"1", "2", "3"}},
+		{name: "partially delivered", accepted: 2, want: []string{"3"}},
+		{name: "all delivered", accepted: 3, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			first := &scriptedPublisher{accept: tt.accepted}
+			outbox := openTestOutbox(t, dir, first)
+			publishEvents(t, outbox, "1", "2", "3")
+			waitForDepth(t, outbox, 3-tt.accepted)
+			if err := outbox.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+
+			second := &scriptedPublisher{accept: 100}
+			outbox = openTestOutbox(t, dir, second)
+			flushAndClose(t, outbox)
+
+			if got := strings.Join(second.ids(), ","); got != strings.Join(tt.want, ",") {
+				t.Errorf("replayed %q, want %q", got, strings.Join(tt.want, ","))
+			}
+		})
+	}
+}
+
+func TestOutboxKeepsEventsPublishedAfterDraining(t *testing.T) {
+	dir := t.TempDir()
+
+	publisher := &scriptedPublisher{accept: 2}
+	outbox := openTestOutbox(t, dir, publisher)
+
+	publishEvents(t, outbox, "1", "2")
+	waitForDepth(t, outbox, 0)
+
+	// These arrive after the log was compacted; they must not be hidden
+	// behind the offset of the events acknowledged before.
+	publishEvents(t, outbox, "3", "4")
+	waitForDepth(t, outbox, 2)
+	if err := outbox.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	replay := &scriptedPublisher{accept: 100}
+	outbox = openTestOutbox(t, dir, replay)
+	flushAndClose(t, outbox)
+
+	if got := strings.Join(replay.ids(), ","); got != "3,4" {
+		t.Errorf("replayed %q, want %q", got, "3,4")
+	}
+}
+
+func TestOutboxLoadsOnDiskState(t *testing.T) {
+	lines := func(ids ...string) string {
+		var b strings.Builder
+		for _, id := range ids {
+			fmt.Fprintf(&b, "{\"id\":%q,\"type\":\"task.created\",\"task_id\":\"t\",\"timestamp\":\"2025-01-01T00:00:00Z\"}\n", id)
+		}
+		return b.String()
+	}
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name:  "legacy offset",
+			files: map[string]string{"outbox.jsonl": lines("1", "2", "3"), "outbox.offset": "1"},
+			want:  "2,3",
+		},
+		{
+			name:  "generation offset",
+			files: map[string]string{"outbox.4.jsonl": lines("1", "2", "3"), "outbox.offset": "4 2"},
+			want:  "3",
+		},
+		{
+			name: "crash before compaction commit",
+			files: map[string]string{
+				"outbox.4.jsonl": lines("1", "2", "3"),
+				"outbox.5.jsonl": lines("3"),
+				"outbox.offset":  "4 2",
+			},
+			want: "3",
+		},
+		{
+			name:  "corrupt entry",
+			files: map[string]string{"outbox.jsonl": lines("1") + "{not json\n" + lines("2")},
+			want:  "1,2",
+		},
+		{
+			name:  "missing log",
+			files: map[string]string{"outbox.offset": "7 3"},
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, data := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			publisher := &scriptedPublisher{accept: 100}
+			outbox := openTestOutbox(t, dir, publisher)
+			flushAndClose(t, outbox)
+
+			if got := strings.Join(publisher.ids(), ","); got != tt.want {
+				t.Errorf("delivered %q, want %q", got, tt.want)
+			}
+
+			logs, _ := filepath.Glob(filepath.Join(dir, "outbox*.jsonl"))
+			if len(logs) != 1 {
+				t.Errorf("found %d log generations, want 1: %v", len(logs), logs)
+			}
+		})
+	}
+}
+
+// blockingPublisher never delivers; it waits until the context is cancelled.
+type blockingPublisher struct{}
+
+func (blockingPublisher) Publish(ctx context.Context, event Event) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (blockingPublisher) Close() error {
+	return nil
+}
+
+func TestOutboxCloseCancelsInFlightPublish(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg := testOutboxConfig(dir)
+	cfg.CloseTimeout = time.Second
+	outbox, err := NewOutbox(cfg, blockingPublisher{})
+	if err != nil {
+		t.Fatalf("NewOutbox: %v", err)
+	}
+	publishEvents(t, outbox, "1")
+
+	start := time.Now()
+	if err := outbox.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Close took %s, want it to cancel the in-flight publish", elapsed)
+	}
+
+	second := &scriptedPublisher{accept: 100}
+	outbox = openTestOutbox(t, dir, second)
+	flushAndClose(t, outbox)
+
+	if got := strings.Join(second.ids(), ","); got != "1" {
+		t.Errorf("replayed %q, want %q", got, "1")
+	}
+}
+
+func TestOutboxCompactsAfterThreshold(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg := testOutboxConfig(dir)
+	cfg.CompactEvery = 3
+	outbox, err := NewOutbox(cfg, &scriptedPublisher{accept: 100})
+	if err != nil {
+		t.Fatalf("NewOutbox: %v", err)
+	}
+	defer outbox.Close()
+
+	generation := func() string {
+		data, err := os.ReadFile(filepath.Join(dir, outboxOffsetFile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		gen, _ := parseOffset(string(data))
+		return fmt.Sprint(gen)
+	}
+	initial := generation()
+
+	publishEvents(t, outbox, "1", "2")
+	waitForDepth(t, outbox, 0)
+	if got := generation(); got != initial {
+		t.Errorf("generation = %s after 2 acks, want %s (no compaction yet)", got, initial)
+	}
+
+	publishEvents(t, outbox, "3")
+	waitForDepth(t, outbox, 0)
+	if got := generation(); got == initial {
+		t.Errorf("generation = %s after 3 acks, want a compacted generation", got)
+	}
+}
+
+func TestOutboxBackoffIgnoresNewEvents(t *testing.T) {
+	const backoff = 100 * time.Millisecond
+
+	publisher := &scriptedPublisher{}
+	cfg := config.OutboxConfig{Dir: t.TempDir(), MinBackoff: backoff, MaxBackoff: backoff}
+	outbox, err := NewOutbox(cfg, publisher)
+	if err != nil {
+		t.Fatalf("NewOutbox: %v", err)
+	}
+	defer outbox.Close()
+
+	start := time.Now()
+	for i := range 30 {
+		publishEvents(t, outbox, fmt.Sprint(i))
+		time.Sleep(10 * time.Millisecond)
+	}
+	elapsed := time.Since(start)
+
+	publisher.mu.Lock()
+	attempts := publisher.attempts
+	publisher.mu.Unlock()
+
+	// jitter waits at least half the backoff between attempts.
+	if limit := int(elapsed/(backoff/2)) + 1; attempts > limit {
+		t.Errorf("attempts = %d in %s, want at most %d", attempts, elapsed, limit)
+	}
+}
+
+func TestParseOffset(t *testing.T) {
+	tests := []struct {
+		data      string
+		wantGen   int
+		wantAcked int
+	}{
+		{data: "", wantGen: 0, wantAcked: 0},
+		{data: "5", wantGen: 0, wantAcked: 5},
+		{data: "5\n", wantGen: 0, wantAcked: 5},
+		{data: "3 7", wantGen: 3, wantAcked: 7},
+		{data: "1 2 3", wantGen: 0, wantAcked: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			gen, acked := parseOffset(tt.data)
+			if gen != tt.wantGen || acked != tt.wantAcked {
+				t.Errorf("parseOffset(%q) = %d, %d; want %d, %d", tt.data, gen, acked, tt.wantGen, tt.wantAcked)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package history
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// compactSlack is how many dropped revisions the file may hold before it
+// is rewritten, so small stores are not rewritten on every append.
+const compactSlack = 1000
+
+// FileStore appends revisions to a JSON lines file and serves reads from an
+// in-memory index built when the file is opened. The index keeps the same
+// per-task cap and purge retention as MemoryStore, and the file is rewritten without the
+// dropped revisions once they outnumber the kept ones, so neither grows
+// without bound. Every append is synced to disk before it returns.
+type FileStore struct {
+	path string
+
+	mu     sync.Mutex
+	file   *os.File
+	lines  int
+	memory *MemoryStore
+}
+
+func NewFileStore(path string, maxPerTask int, purgedRetention time.Duration) (*FileStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	s := &FileStore{
+		path:   path,
+		memory: NewMemoryStore(maxPerTask, purgedRetention),
+	}
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	if s.lines > s.memory.len() {
+		if err := s.compact(); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *FileStore) Append(ctx context.Context, revision Revision) error {
+	line, err := json.Marshal(revision)
+	if err != nil {
+		return fmt.Errorf("failed to marshal revision: %w", err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write revision: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync history file: %w", err)
+	}
+	s.lines++
+
+	if err := s.memory.Append(ctx, revision); err != nil {
+		return err
+	}
+
+	// The revision is already stored; a failed compaction leaves the old
+	// file in place and is retried on a later append.
+	if s.lines > 2*s.memory.len()+compactSlack {
+		if err := s.compact(); err != nil {
+			slog.WarnContext(ctx, "Failed to compact history file",
+				slog.String("path", s.path),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *FileStore) List(ctx context.Context, taskID string) ([]Revision, error) {
+	return s.memory.List(ctx, taskID)
+}
+
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
+
+func (s *FileStore) open() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	s.file = file
+
+	return nil
+}
+
+// load streams the file into the in-memory index, which drops revisions
+// beyond the per-task cap and those of long purged tasks as it goes.
+func (s *FileStore) load() error {
+	file, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	for scanner.Scan() {
+		s.lines++
+
+		var revision Revision
+		if err := json.Unmarshal(scanner.Bytes(), &revision); err != nil {
+			slog.Warn("Skipping corrupt history entry",
+				slog.Int("line", s.lines),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		s.memory.Append(context.Background(), revision)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	return nil
+}
+
+// compact rewrites the file with only the kept revisions and reopens it
+// for appending. The new file replaces the old one atomically, so the old
+// file stays usable until the rename succeeds.
+func (s *FileStore) compact() error {
+	revisions := s.memory.all()
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create history file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	writer := bufio.NewWriter(tmp)
+	encoder := json.NewEncoder(writer)
+	for _, revision := range revisions {
+		if err := encoder.Encode(revision); err != nil {
+			tmp.Close()
+			return fmt.Errorf("failed to write revision: %w", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync history file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close history file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace history file: %w", err)
+	}
+	s.lines = len(revisions)
+
+	if s.file != nil {
+		s.file.Close()
+	}
+
+	slog.Info("History file compacted",
+		slog.String("path", s.path),
+		slog.Int("revisions", len(revisions)),
+	)
+
+	return s.open()
+}
@@ -0,0 +1,162 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/config"
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+)
+
+const (
+	OperationCreate  = "create"
+	OperationUpdate  = "update"
+	OperationDelete  = "delete"
+	OperationRestore = "restore"
+	OperationPurge   = "purge"
+
+	StoreMemory = "memory"
+	StoreFile   = "file"
+)
+
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+type Revision struct {
+	ID        string        `json:"id"`
+	TaskID    string        `json:"task_id"`
+	Version   int64         `json:"version"`
+	Actor     string        `json:"actor"`
+	Operation string        `json:"operation"`
+	Timestamp time.Time     `json:"timestamp"`
+	Changes   []FieldChange `json:"changes"`
+}
+
+// Store keeps task revisions. List returns them oldest first.
+type Store interface {
+	Append(ctx context.Context, revision Revision) error
+	List(ctx context.Context, taskID string) ([]Revision, error)
+}
+
+func NewStore(cfg config.HistoryConfig) (Store, error) {
+	switch cfg.Store {
+	case StoreMemory:
+		return NewMemoryStore(cfg.MaxRevisionsPerTask, cfg.PurgedRetention), nil
+	case StoreFile:
+		return NewFileStore(cfg.File, cfg.MaxRevisionsPerTask, cfg.PurgedRetention)
+	default:
+		return nil, fmt.Errorf("unknown history store %q (use %q or %q)", cfg.Store, StoreMemory, StoreFile)
+	}
+}
+
+// Diff lists the user-visible fields that differ between two states of a
+// task. A nil state stands for a task that does not exist. Checklist items
+// are compared by ID and reported as "items.<id>.<field>", so a revision
+// shows which item was ticked off.
+func Diff(before, after *model.Task) []FieldChange {
+	beforeFields := taskFields(before)
+	afterFields := taskFields(after)
+
+	var changes []FieldChange
+	for _, field := range trackedFields {
+		b, a := beforeFields[field], afterFields[field]
+		if reflect.DeepEqual(b, a) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: field, Before: b, After: a})
+	}
+
+	return append(changes, diffItems(taskItems(before), taskItems(after))...)
+}
+
+var trackedItemFields = []string{"text", "done", "position"}
+
+func diffItems(before, after []model.ChecklistItem) []FieldChange {
+	beforeItems := make(map[string]model.ChecklistItem, len(before))
+	ids := make([]string, 0, len(before)+len(after))
+	for _, item := range before {
+		beforeItems[item.ID] = item
+		ids = append(ids, item.ID)
+	}
+
+	afterItems := make(map[string]model.ChecklistItem, len(after))
+	for _, item := range after {
+		afterItems[item.ID] = item
+		if _, ok := beforeItems[item.ID]; !ok {
+			ids = append(ids, item.ID)
+		}
+	}
+
+	var changes []FieldChange
+	for _, id := range ids {
+		beforeFields := itemFields(beforeItems, id)
+		afterFields := itemFields(afterItems, id)
+
+		for _, field := range trackedItemFields {
+			b, a := beforeFields[field], afterFields[field]
+			if b == a {
+				continue
+			}
+			changes = append(changes, FieldChange{Field: "items." + id + "." + field, Before: b, After: a})
+		}
+	}
+
+	return changes
+}
+
+func taskItems(task *model.Task) []model.ChecklistItem {
+	if task == nil {
+		return nil
+	}
+	return task.Items
+}
+
+func itemFields(items map[string]model.ChecklistItem, id string) map[string]any {
+	item, ok := items[id]
+	if !ok {
+		return map[string]any{}
+	}
+
+	return map[string]any{
+		"text":     item.Text,
+		"done":     item.Done,
+		"position": item.Position,
+	}
+}
+
+var trackedFields = []string{"title", "description", "completed", "due_at", "priority", "tags", "list_id", "deleted_at"}
+
+func taskFields(task *model.Task) map[string]any {
+	if task == nil {
+		return map[string]any{}
+	}
+
+	tags := slices.Clone(task.Tags)
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return map[string]any{
+		"title":       task.Title,
+		"description": task.Description,
+		"completed":   task.Completed,
+		"due_at":      timeValue(task.DueAt),
+		"priority":    string(task.Priority),
+		"tags":        tags,
+		"list_id":     task.ListID,
+		"deleted_at":  timeValue(task.DeletedAt),
+	}
+}
+
+func timeValue(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
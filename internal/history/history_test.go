@@ -0,0 +1,92 @@
+package history
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+)
+
+func TestDiff(t *testing.T) {
+	task := func(items ...model.ChecklistItem) *model.Task {
+		return &model.Task{ID: "task-1", Title: "Pack", Priority: model.PriorityMedium, Items: items}
+	}
+	passport := model.ChecklistItem{ID: "item-1", Text: "Passport", Position: 0}
+	charger := model.ChecklistItem{ID: "item-2", Text: "Charger", Position: 1}
+	packedPassport := passport
+	packedPassport.Done = true
+	movedCharger := charger
+	movedCharger.Position = 0
+
+	tests := []struct {
+		name   string
+		before *model.Task
+		after  *model.Task
+		want   []FieldChange
+	}{
+		{
+			name:   "unchanged",
+			before: task(passport),
+			after:  task(passport),
+		},
+		{
+			name:   "task field",
+			before: task(passport),
+			after:  &model.Task{ID: "task-1", Title: "Unpack", Priority: model.PriorityMedium, Items: []model.ChecklistItem{passport}},
+			want:   []FieldChange{{Field: "title", Before: "Pack", After: "Unpack"}},
+		},
+		{
+			name:   "item ticked off",
+			before: task(passport, charger),
+			after:  task(packedPassport, charger),
+			want:   []FieldChange{{Field: "items.item-1.done", Before: false, After: true}},
+		},
+		{
+			name:   "item added",
+			before: task(passport),
+			after:  task(passport, charger),
+			want: []FieldChange{
+				{Field: "items.item-2.text", Before: nil, After: "Charger"},
+				{Field: "items.item-2.done", Before: nil, After: false},
+				{Field: "items.item-2.position", Before: nil, After: 1},
+			},
+		},
+		{
+			name:   "item removed and the rest moved up",
+			before: task(passport, charger),
+			after:  task(movedCharger),
+			want: []FieldChange{
+				{Field: "items.item-1.text", Before: "Passport", After: nil},
+				{Field: "items.item-1.done", Before: false, After: nil},
+				{Field: "items.item-1.position", Before: 0, After: nil},
+				{Field: "items.item-2.position", Before: 1, After: 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Diff(tt.before, tt.after)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffPurgedTask(t *testing.T) {
+	before := &model.Task{ID: "task-1", Title: "Pack", Items: []model.ChecklistItem{{ID: "item-1", Text: "Passport"}}}
+
+	changes := Diff(before, nil)
+
+	fields := make(map[string]bool)
+	for _, change := range changes {
+		if change.After != nil {
+			t.Errorf("change %q has after = %v, want nil", change.Field, change.After)
+		}
+		fields[change.Field] = true
+	}
+	if !fields["title"] || !fields["items.item-1.text"] {
+		t.Errorf("changes = %+v, want the title and the item", changes)
+	}
+}
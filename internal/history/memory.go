@@ -0,0 +1,108 @@
+package history
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps revisions in process memory. It is meant for
+// development; history is lost on restart. Each task keeps at most
+// maxPerTask revisions; older ones are dropped as new ones arrive.
+// Revisions of a purged task are dropped purgedRetention after the purge,
+// since the task's history can no longer be requested.
+type MemoryStore struct {
+	maxPerTask      int
+	purgedRetention time.Duration
+
+	mu        sync.RWMutex
+	revisions map[string][]Revision
+	count     int
+	// purged lists purged tasks in the order of their purge revisions.
+	purged []purgedTask
+}
+
+type purgedTask struct {
+	taskID   string
+	purgedAt time.Time
+}
+
+func NewMemoryStore(maxPerTask int, purgedRetention time.Duration) *MemoryStore {
+	return &MemoryStore{
+		maxPerTask:      maxPerTask,
+		purgedRetention: purgedRetention,
+		revisions:       make(map[string][]Revision),
+	}
+}
+
+func (s *MemoryStore) Append(ctx context.Context, revision Revision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revisions := append(s.revisions[revision.TaskID], revision)
+	s.count++
+
+	if excess := len(revisions) - s.maxPerTask; s.maxPerTask > 0 && excess > 0 {
+		revisions = slices.Delete(revisions, 0, excess)
+		s.count -= excess
+	}
+
+	s.revisions[revision.TaskID] = revisions
+
+	if revision.Operation == OperationPurge {
+		s.purged = append(s.purged, purgedTask{taskID: revision.TaskID, purgedAt: revision.Timestamp})
+	}
+	s.dropExpired(time.Now())
+
+	return nil
+}
+
+// dropExpired removes the revisions of tasks purged more than
+// purgedRetention before now.
+func (s *MemoryStore) dropExpired(now time.Time) {
+	cutoff := now.Add(-s.purgedRetention)
+
+	expired := 0
+	for _, task := range s.purged {
+		if task.purgedAt.After(cutoff) {
+			break
+		}
+		s.count -= len(s.revisions[task.taskID])
+		delete(s.revisions, task.taskID)
+		expired++
+	}
+
+	s.purged = slices.Delete(s.purged, 0, expired)
+}
+
+func (s *MemoryStore) List(ctx context.Context, taskID string) ([]Revision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Clone(s.revisions[taskID]), nil
+}
+
+func (s *MemoryStore) len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.count
+}
+
+// all returns every kept revision ordered by timestamp.
+func (s *MemoryStore) all() []Revision {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	revisions := make([]Revision, 0, s.count)
+	for _, taskRevisions := range s.revisions {
+		revisions = append(revisions, taskRevisions...)
+	}
+
+	slices.SortStableFunc(revisions, func(a, b Revision) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	return revisions
+}
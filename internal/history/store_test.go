@@ -0,0 +1,232 @@
+package history
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func testRevision(taskID string, n int) Revision {
+	return Revision{
+		ID:        fmt.Sprintf("%s-%d", taskID, n),
+		TaskID:    taskID,
+		Version:   int64(n),
+		Operation: OperationUpdate,
+		Timestamp: time.Date(2024, 5, 1, 12, 0, n, 0, time.UTC),
+	}
+}
+
+func revisionIDs(t *testing.T, store Store, taskID string) []string {
+	t.Helper()
+
+	revisions, err := store.List(context.Background(), taskID)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	ids := make([]string, len(revisions))
+	for i, revision := range revisions {
+		ids[i] = revision.ID
+	}
+	return ids
+}
+
+func countLines(t *testing.T, path string) int {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer file.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines++
+	}
+	return lines
+}
+
+func TestMemoryStoreKeepsNewestRevisions(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxPerTask int
+		appends    int
+		want       []string
+	}{
+		{name: "under the cap", maxPerTask: 3, appends: 2, want: []string{"task-1-0", "task-1-1"}},
+		{name: "at the cap", maxPerTask: 3, appends: 3, want: []string{"task-1-0", "task-1-1", "task-1-2"}},
+		{name: "over the cap", maxPerTask: 3, appends: 5, want: []string{"task-1-2", "task-1-3", "task-1-4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore(tt.maxPerTask, time.Hour)
+			for i := range tt.appends {
+				store.Append(context.Background(), testRevision("task-1", i))
+			}
+			store.Append(context.Background(), testRevision("task-2", 0))
+
+			got := revisionIDs(t, store, "task-1")
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("revisions = %v, want %v", got, tt.want)
+			}
+			if want := len(tt.want) + 1; store.len() != want {
+				t.Errorf("len() = %d, want %d", store.len(), want)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreDropsPurgedTasks(t *testing.T) {
+	tests := []struct {
+		name     string
+		purgedAt time.Time
+		want     []string
+	}{
+		{name: "within retention", purgedAt: time.Now().Add(-time.Minute), want: []string{"task-1-0", "task-1-1", "task-1-purge"}},
+		{name: "after retention", purgedAt: time.Now().Add(-2 * time.Hour), want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore(10, time.Hour)
+			for i := range 2 {
+				store.Append(context.Background(), testRevision("task-1", i))
+			}
+			store.Append(context.Background(), Revision{
+				ID:        "task-1-purge",
+				TaskID:    "task-1",
+				Operation: OperationPurge,
+				Timestamp: tt.purgedAt,
+			})
+			store.Append(context.Background(), testRevision("task-2", 0))
+
+			got := revisionIDs(t, store, "task-1")
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("revisions = %v, want %v", got, tt.want)
+			}
+			if want := len(tt.want) + 1; store.len() != want {
+				t.Errorf("len() = %d, want %d", store.len(), want)
+			}
+		})
+	}
+}
+
+func TestFileStoreReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	store, err := NewFileStore(path, 10, time.Hour)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	for i := range 3 {
+		if err := store.Append(context.Background(), testRevision("task-1", i)); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	store.Close()
+
+	reopened, err := NewFileStore(path, 10, time.Hour)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	defer reopened.Close()
+
+	if got := revisionIDs(t, reopened, "task-1"); len(got) != 3 || got[0] != "task-1-0" || got[2] != "task-1-2" {
+		t.Errorf("revisions after reopen = %v, want task-1-0..2", got)
+	}
+}
+
+func TestFileStoreCompactsOnOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	store, err := NewFileStore(path, 10, time.Hour)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	for i := range 6 {
+		store.Append(context.Background(), testRevision("task-1", i))
+	}
+	store.Close()
+
+	reopened, err := NewFileStore(path, 2, time.Hour)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+
+	if got := revisionIDs(t, reopened, "task-1"); !slices.Equal(got, []string{"task-1-4", "task-1-5"}) {
+		t.Errorf("revisions = %v, want the newest two", got)
+	}
+	if lines := countLines(t, path); lines != 2 {
+		t.Errorf("file has %d lines after compaction, want 2", lines)
+	}
+
+	if err := reopened.Append(context.Background(), testRevision("task-1", 6)); err != nil {
+		t.Fatalf("Append after compaction: %v", err)
+	}
+	reopened.Close()
+
+	if lines := countLines(t, path); lines != 3 {
+		t.Errorf("file has %d lines after append, want 3", lines)
+	}
+}
+
+func TestFileStoreDropsPurgedTasksOnOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	store, err := NewFileStore(path, 10, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	store.Append(context.Background(), testRevision("task-1", 0))
+	store.Append(context.Background(), Revision{
+		ID:        "task-1-purge",
+		TaskID:    "task-1",
+		Operation: OperationPurge,
+		Timestamp: time.Now().Add(-2 * time.Hour),
+	})
+	store.Append(context.Background(), testRevision("task-2", 0))
+	store.Close()
+
+	reopened, err := NewFileStore(path, 10, time.Hour)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	defer reopened.Close()
+
+	if got := revisionIDs(t, reopened, "task-1"); len(got) != 0 {
+		t.Errorf("revisions of the purged task = %v, want none", got)
+	}
+	if lines := countLines(t, path); lines != 1 {
+		t.Errorf("file has %d lines after open, want 1", lines)
+	}
+}
+
+func TestFileStoreCompactsWhileRunning(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	store, err := NewFileStore(path, 1, time.Hour)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	defer store.Close()
+
+	for i := range compactSlack + 10 {
+		if err := store.Append(context.Background(), testRevision("task-1", i)); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	if lines := countLines(t, path); lines > compactSlack {
+		t.Errorf("file has %d lines, want it compacted below %d", lines, compactSlack)
+	}
+	if got := revisionIDs(t, store, "task-1"); len(got) != 1 || got[0] != fmt.Sprintf("task-1-%d", compactSlack+9) {
+		t.Errorf("revisions = %v, want only the newest", got)
+	}
+}
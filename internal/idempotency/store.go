@@ -0,0 +1,147 @@
+package idempotency
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	ErrFingerprintMismatch = errors.New("idempotency key was already used with a different request")
+	ErrInProgress          = errors.New("a request with this idempotency key is still in progress")
+	ErrStoreFull           = errors.New("too many idempotent requests are in progress")
+)
+
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+type entry struct {
+	key         string
+	fingerprint string
+	response    *Response
+	expiresAt   time.Time
+}
+
+// Store remembers responses by idempotency key for a bounded time. When it
+// is full the least recently stored completed keys are evicted first;
+// in-progress reservations are never evicted, so new keys are rejected with
+// ErrStoreFull until one of them completes or is released.
+type Store struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+func NewStore(ttl time.Duration, maxEntries int) *Store {
+	return &Store{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Begin reserves key for a request with the given fingerprint. It returns the
+// stored response when the request was already completed, and nil when the
+// caller should process the request and then call Complete or Release.
+func (s *Store) Begin(key, fingerprint string, now time.Time) (*Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.entries[key]; ok {
+		e := elem.Value.(*entry)
+		if e.response != nil && now.After(e.expiresAt) {
+			s.remove(elem)
+		} else {
+			if e.fingerprint != fingerprint {
+				return nil, ErrFingerprintMismatch
+			}
+			if e.response == nil {
+				return nil, ErrInProgress
+			}
+			return e.response, nil
+		}
+	}
+
+	s.evict(now)
+	if s.order.Len() >= s.maxEntries {
+		return nil, ErrStoreFull
+	}
+
+	s.entries[key] = s.order.PushBack(&entry{key: key, fingerprint: fingerprint})
+
+	return nil, nil
+}
+
+func (s *Store) Complete(key string, response *Response, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.entries[key]; ok {
+		e := elem.Value.(*entry)
+		e.response = response
+		e.expiresAt = now.Add(s.ttl)
+	}
+}
+
+// Release forgets an in-flight key so the request can be retried.
+func (s *Store) Release(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.entries[key]; ok {
+		s.remove(elem)
+	}
+}
+
+func (s *Store) evict(now time.Time) {
+	for elem := s.order.Front(); elem != nil; {
+		next := elem.Next()
+		e := elem.Value.(*entry)
+		if e.response != nil {
+			if !now.After(e.expiresAt) {
+				break
+			}
+			s.remove(elem)
+		}
+		elem = next
+	}
+
+	// Make room for one more key by dropping the oldest completed entries.
+	for elem := s.order.Front(); elem != nil && s.order.Len() >= s.maxEntries; {
+		next := elem.Next()
+		if elem.Value.(*entry).response != nil {
+			s.remove(elem)
+		}
+		elem = next
+	}
+}
+
+func (s *Store) remove(elem *list.Element) {
+	delete(s.entries, elem.Value.(*entry).key)
+	s.order.Remove(elem)
+}
+
+type contextKey string
+
+const keyContextKey contextKey = "idempotency_key"
+
+func WithKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, keyContextKey, key)
+}
+
+func KeyFromContext(ctx context.Context) string {
+	if key, ok := ctx.Value(keyContextKey).(string); ok {
+		return key
+	}
+	return ""
+}
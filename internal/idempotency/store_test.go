@@ -0,0 +1,206 @@
+package idempotency
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+var storeTestTime = time.Unix(1700000000, 0)
+
+func TestStoreBegin(t *testing.T) {
+	response := &Response{StatusCode: http.StatusCreated, Body: []byte(`{"id":"1"}`)}
+
+	tests := []struct {
+		name         string
+		setup        func(s *Store)
+		key          string
+		fingerprint  string
+		at           time.Duration
+		wantResponse *Response
+		wantErr      error
+	}{
+		{
+			name:        "new key",
+			setup:       func(s *Store) {},
+			key:         "a",
+			fingerprint: "f1",
+		},
+		{
+			name: "completed key replays",
+			setup: func(s *Store) {
+				s.Begin("a", "f1", storeTestTime)
+				s.Complete("a", response, storeTestTime)
+			},
+			key:          "a",
+			fingerprint:  "f1",
+			at:           time.Minute,
+			wantResponse: response,
+		},
+		{
+			name: "different fingerprint",
+			setup: func(s *Store) {
+				s.Begin("a", "f1", storeTestTime)
+				s.Complete("a", response, storeTestTime)
+			},
+			key:         "a",
+			fingerprint: "f2",
+			wantErr:     ErrFingerprintMismatch,
+		},
+		{
+			name: "in progress",
+			setup: func(s *Store) {
+				s.Begin("a", "f1", storeTestTime)
+			},
+			key:         "a",
+			fingerprint: "f1",
+			wantErr:     ErrInProgress,
+		},
+		{
+			name: "in progress with different fingerprint",
+			setup: func(s *Store) {
+				s.Begin("a", "f1", storeTestTime)
+			},
+			key:         "a",
+			fingerprint: "f2",
+			wantErr:     ErrFingerprintMismatch,
+		},
+		{
+			name: "released key starts over",
+			setup: func(s *Store) {
+				s.Begin("a", "f1", storeTestTime)
+				s.Release("a")
+			},
+			key:         "a",
+			fingerprint: "f2",
+		},
+		{
+			name: "expired key starts over",
+			setup: func(s *Store) {
+				s.Begin("a", "f1", storeTestTime)
+				s.Complete("a", response, storeTestTime)
+			},
+			key:         "a",
+			fingerprint: "f2",
+			at:          time.Hour + time.Second,
+		},
+		{
+			name: "key is replayed until the TTL ends",
+			setup: func(s *Store) {
+				s.Begin("a", "f1", storeTestTime)
+				s.Complete("a", response, storeTestTime)
+			},
+			key:          "a",
+			fingerprint:  "f1",
+			at:           time.Hour,
+			wantResponse: response,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStore(time.Hour, 10)
+			tt.setup(s)
+
+			got, err := s.Begin(tt.key, tt.fingerprint, storeTestTime.Add(tt.at))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.wantResponse {
+				t.Errorf("response = %v, want %v", got, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func TestStoreEviction(t *testing.T) {
+	response := &Response{StatusCode: http.StatusOK}
+
+	tests := []struct {
+		name        string
+		maxEntries  int
+		keys        []string
+		at          time.Duration
+		wantKept    []string
+		wantEvicted []string
+	}{
+		{
+			name:        "oldest key is evicted when full",
+			maxEntries:  2,
+			keys:        []string{"a", "b", "c"},
+			wantKept:    []string{"b", "c"},
+			wantEvicted: []string{"a"},
+		},
+		{
+			name:       "within capacity",
+			maxEntries: 3,
+			keys:       []string{"a", "b", "c"},
+			wantKept:   []string{"a", "b", "c"},
+		},
+		{
+			name:        "expired keys are dropped on insert",
+			maxEntries:  10,
+			keys:        []string{"a", "b"},
+			at:          2 * time.Hour,
+			wantKept:    []string{"b"},
+			wantEvicted: []string{"a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStore(time.Hour, tt.maxEntries)
+
+			// Every key after the first is stored at the test's offset.
+			for i, key := range tt.keys {
+				now := storeTestTime
+				if i > 0 {
+					now = now.Add(tt.at)
+				}
+				s.Begin(key, "f", now)
+				s.Complete(key, response, now)
+			}
+
+			for _, key := range tt.wantKept {
+				if _, ok := s.entries[key]; !ok {
+					t.Errorf("key %q was evicted", key)
+				}
+			}
+			for _, key := range tt.wantEvicted {
+				if _, ok := s.entries[key]; ok {
+					t.Errorf("key %q was kept", key)
+				}
+			}
+			if s.order.Len() != len(s.entries) {
+				t.Errorf("order has %d entries, index has %d", s.order.Len(), len(s.entries))
+			}
+		})
+	}
+}
+
+func TestStoreKeepsInProgressKeysWhenFull(t *testing.T) {
+	s := NewStore(time.Hour, 2)
+
+	s.Begin("a", "f", storeTestTime)
+	s.Begin("b", "f", storeTestTime)
+
+	if _, err := s.Begin("c", "f", storeTestTime); !errors.Is(err, ErrStoreFull) {
+		t.Fatalf("Begin(c) error = %v, want %v", err, ErrStoreFull)
+	}
+	if _, err := s.Begin("a", "f", storeTestTime); !errors.Is(err, ErrInProgress) {
+		t.Errorf("Begin(a) error = %v, want the reservation to survive", err)
+	}
+
+	// Once a key completes it becomes evictable, making room for a new one.
+	s.Complete("a", &Response{StatusCode: http.StatusOK}, storeTestTime)
+	if _, err := s.Begin("c", "f", storeTestTime); err != nil {
+		t.Fatalf("Begin(c) after completion: %v", err)
+	}
+	if _, ok := s.entries["a"]; ok {
+		t.Error("completed key a was kept")
+	}
+	if _, err := s.Begin("b", "f", storeTestTime); !errors.Is(err, ErrInProgress) {
+		t.Errorf("Begin(b) error = %v, want the reservation to survive", err)
+	}
+}
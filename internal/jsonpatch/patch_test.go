@@ -0,0 +1,296 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string
+		wantErr error
+	}{
+		// add
+		{
+			name:  "add object member",
+			doc:   `{"a": 1}`,
+			patch: `[{"op": "add", "path": "/b", "value": 2}]`,
+			want:  `{"a": 1, "b": 2}`,
+		},
+		{
+			name:  "add replaces an existing member",
+			doc:   `{"a": 1}`,
+			patch: `[{"op": "add", "path": "/a", "value": [1, 2]}]`,
+			want:  `{"a": [1, 2]}`,
+		},
+		{
+			name:  "add null value",
+			doc:   `{}`,
+			patch: `[{"op": "add", "path": "/a", "value": null}]`,
+			want:  `{"a": null}`,
+		},
+		{
+			name:  "add inserts into an array",
+			doc:   `{"a": ["x", "z"]}`,
+			patch: `[{"op": "add", "path": "/a/1", "value": "y"}]`,
+			want:  `{"a": ["x", "y", "z"]}`,
+		},
+		{
+			name:  "add at the array length appends",
+			doc:   `{"a": ["x"]}`,
+			patch: `[{"op": "add", "path": "/a/1", "value": "y"}]`,
+			want:  `{"a": ["x", "y"]}`,
+		},
+		{
+			name:  "add with - appends",
+			doc:   `{"a": ["x"]}`,
+			patch: `[{"op": "add", "path": "/a/-", "value": {"b": 1}}]`,
+			want:  `{"a": ["x", {"b": 1}]}`,
+		},
+		{
+			name:  "add replaces the whole document",
+			doc:   `{"a": 1}`,
+			patch: `[{"op": "add", "path": "", "value": {"b": 2}}]`,
+			want:  `{"b": 2}`,
+		},
+		{
+			name:    "add past the array end",
+			doc:     `{"a": ["x"]}`,
+			patch:   `[{"op": "add", "path": "/a/2", "value": "y"}]`,
+			wantErr: ErrPathNotFound,
+		},
+		{
+			name:    "add to a missing parent",
+			doc:     `{}`,
+			patch:   `[{"op": "add", "path": "/a/b", "value": 1}]`,
+			wantErr: ErrPathNotFound,
+		},
+		{
+			name:    "add without a value",
+			doc:     `{}`,
+			patch:   `[{"op": "add", "path": "/a"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+
+		// remove
+		{
+			name:  "remove object member",
+			doc:   `{"a": 1, "b": 2}`,
+			patch: `[{"op": "remove", "path": "/a"}]`,
+			want:  `{"b": 2}`,
+		},
+		{
+			name:  "remove array element",
+			doc:   `{"a": ["x", "y", "z"]}`,
+			patch: `[{"op": "remove", "path": "/a/1"}]`,
+			want:  `{"a": ["x", "z"]}`,
+		},
+		{
+			name:    "remove missing member",
+			doc:     `{"a": 1}`,
+			patch:   `[{"op": "remove", "path": "/b"}]`,
+			wantErr: ErrPathNotFound,
+		},
+		{
+			name:    "remove with - is not an index",
+			doc:     `{"a": ["x"]}`,
+			patch:   `[{"op": "remove", "path": "/a/-"}]`,
+			wantErr: ErrPathNotFound,
+		},
+		{
+			name:    "remove the whole document",
+			doc:     `{"a": 1}`,
+			patch:   `[{"op": "remove", "path": ""}]`,
+			wantErr: ErrInvalidPatch,
+		},
+
+		// replace
+		{
+			name:  "replace object member",
+			doc:   `{"a": 1}`,
+			patch: `[{"op": "replace", "path": "/a", "value": "one"}]`,
+			want:  `{"a": "one"}`,
+		},
+		{
+			name:  "replace nested array element",
+			doc:   `{"a": [{"done": false}]}`,
+			patch: `[{"op": "replace", "path": "/a/0/done", "value": true}]`,
+			want:  `{"a": [{"done": true}]}`,
+		},
+		{
+			name:    "replace missing member",
+			doc:     `{"a": 1}`,
+			patch:   `[{"op": "replace", "path": "/b", "value": 2}]`,
+			wantErr: ErrPathNotFound,
+		},
+		{
+			name:    "replace with a leading zero index",
+			doc:     `{"a": ["x", "y"]}`,
+			patch:   `[{"op": "replace", "path": "/a/01", "value": "z"}]`,
+			wantErr: ErrPathNotFound,
+		},
+
+		// move
+		{
+			name:  "move object member",
+			doc:   `{"a": {"b": 1}, "c": {}}`,
+			patch: `[{"op": "move", "from": "/a/b", "path": "/c/d"}]`,
+			want:  `{"a": {}, "c": {"d": 1}}`,
+		},
+		{
+			name:  "move array element",
+			doc:   `{"a": ["x", "y", "z"]}`,
+			patch: `[{"op": "move", "from": "/a/2", "path": "/a/0"}]`,
+			want:  `{"a": ["z", "x", "y"]}`,
+		},
+		{
+			name:  "move to the same location",
+			doc:   `{"a": 1}`,
+			patch: `[{"op": "move", "from": "/a", "path": "/a"}]`,
+			want:  `{"a": 1}`,
+		},
+		{
+			name:    "move into a child",
+			doc:     `{"a": {"b": {}}}`,
+			patch:   `[{"op": "move", "from": "/a", "path": "/a/b/c"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "move from a missing path",
+			doc:     `{"a": 1}`,
+			patch:   `[{"op": "move", "from": "/b", "path": "/c"}]`,
+			wantErr: ErrPathNotFound,
+		},
+
+		// copy
+		{
+			name:  "copy object member",
+			doc:   `{"a": {"b": 1}}`,
+			patch: `[{"op": "copy", "from": "/a", "path": "/c"}]`,
+			want:  `{"a": {"b": 1}, "c": {"b": 1}}`,
+		},
+		{
+			name:  "copy is deep",
+			doc:   `{"a": {"b": 1}}`,
+			patch: `[{"op": "copy", "from": "/a", "path": "/c"}, {"op": "replace", "path": "/c/b", "value": 2}]`,
+			want:  `{"a": {"b": 1}, "c": {"b": 2}}`,
+		},
+		{
+			name:  "copy the whole document",
+			doc:   `{"a": 1}`,
+			patch: `[{"op": "copy", "from": "", "path": "/b"}]`,
+			want:  `{"a": 1, "b": {"a": 1}}`,
+		},
+		{
+			name:    "copy without from",
+			doc:     `{"a": 1}`,
+			patch:   `[{"op": "copy", "path": "/b"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:  "copy into an array with -",
+			doc:   `{"a": ["x"]}`,
+			patch: `[{"op": "copy", "from": "/a/0", "path": "/a/-"}]`,
+			want:  `{"a": ["x", "x"]}`,
+		},
+
+		// test
+		{
+			name:  "test passes",
+			doc:   `{"a": {"b": [1, "two"]}}`,
+			patch: `[{"op": "test", "path": "/a", "value": {"b": [1, "two"]}}]`,
+			want:  `{"a": {"b": [1, "two"]}}`,
+		},
+		{
+			name:  "test null",
+			doc:   `{"a": null}`,
+			patch: `[{"op": "test", "path": "/a", "value": null}]`,
+			want:  `{"a": null}`,
+		},
+		{
+			name:    "test fails",
+			doc:     `{"a": 1}`,
+			patch:   `[{"op": "test", "path": "/a", "value": 2}]`,
+			wantErr: ErrTestFailed,
+		},
+		{
+			name:    "failed test stops the patch",
+			doc:     `{"a": 1}`,
+			patch:   `[{"op": "replace", "path": "/a", "value": 2}, {"op": "test", "path": "/a", "value": 1}]`,
+			wantErr: ErrTestFailed,
+		},
+
+		// pointers
+		{
+			name:  "~1 escapes a slash",
+			doc:   `{"a/b": 1}`,
+			patch: `[{"op": "replace", "path": "/a~1b", "value": 2}]`,
+			want:  `{"a/b": 2}`,
+		},
+		{
+			name:  "~0 escapes a tilde",
+			doc:   `{"m~n": 1}`,
+			patch: `[{"op": "remove", "path": "/m~0n"}]`,
+			want:  `{}`,
+		},
+		{
+			name:  "~01 decodes to ~1",
+			doc:   `{"~1": 1}`,
+			patch: `[{"op": "test", "path": "/~01", "value": 1}]`,
+			want:  `{"~1": 1}`,
+		},
+		{
+			name:  "empty member name",
+			doc:   `{"": 1}`,
+			patch: `[{"op": "replace", "path": "/", "value": 2}]`,
+			want:  `{"": 2}`,
+		},
+		{
+			name:    "pointer without a leading slash",
+			doc:     `{"a": 1}`,
+			patch:   `[{"op": "remove", "path": "a"}]`,
+			wantErr: ErrInvalidPath,
+		},
+		{
+			name:    "unknown op",
+			doc:     `{"a": 1}`,
+			patch:   `[{"op": "merge", "path": "/a", "value": 2}]`,
+			wantErr: ErrInvalidPatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc any
+			if err := json.Unmarshal([]byte(tt.doc), &doc); err != nil {
+				t.Fatalf("bad doc: %v", err)
+			}
+			var patch []Operation
+			if err := json.Unmarshal([]byte(tt.patch), &patch); err != nil {
+				t.Fatalf("bad patch: %v", err)
+			}
+
+			got, err := Apply(doc, patch)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			var want any
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatalf("bad want: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				gotJSON, _ := json.Marshal(got)
+				t.Errorf("Apply() = %s, want %s", gotJSON, tt.want)
+			}
+		})
+	}
+}
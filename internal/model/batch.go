@@ -0,0 +1,8 @@
+package model
+
+// BatchResult is the outcome of one operation in a task batch. Task is nil
+// for failed operations and may be nil for deletes.
+type BatchResult struct {
+	Task *Task
+	Err  error
+}
@@ -0,0 +1,23 @@
+package model
+
+type ChecklistItem struct {
+	ID       string
+	Text     string
+	Done     bool
+	Position int
+}
+
+type Progress struct {
+	Done  int
+	Total int
+}
+
+func (t *Task) Progress() Progress {
+	progress := Progress{Total: len(t.Items)}
+	for _, item := range t.Items {
+		if item.Done {
+			progress.Done++
+		}
+	}
+	return progress
+}
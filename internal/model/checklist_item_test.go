@@ -0,0 +1,25 @@
+package model
+
+import "testing"
+
+func TestTaskProgress(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []ChecklistItem
+		want  Progress
+	}{
+		{name: "no items", want: Progress{}},
+		{name: "none done", items: []ChecklistItem{{ID: "a"}, {ID: "b"}}, want: Progress{Done: 0, Total: 2}},
+		{name: "some done", items: []ChecklistItem{{ID: "a", Done: true}, {ID: "b"}, {ID: "c", Done: true}}, want: Progress{Done: 2, Total: 3}},
+		{name: "all done", items: []ChecklistItem{{ID: "a", Done: true}}, want: Progress{Done: 1, Total: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{Items: tt.items}
+			if got := task.Progress(); got != tt.want {
+				t.Errorf("Progress() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -10,8 +10,9 @@ const (
 )
 
 type Health struct {
-	Status    HealthStatus
-	Timestamp time.Time
+	Status      HealthStatus
+	Timestamp   time.Time
+	OutboxDepth *int
 }
 
 func NewHealth(status HealthStatus) *Health {
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+type List struct {
+	ID          string
+	Name        string
+	Description string
+	TaskCount   int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	OwnerID     string
+	Shares      []Share
+}
+
+type ListPage struct {
+	Lists      []*List
+	NextCursor string
+	TotalCount int
+}
+
+// RoleFor returns the role userID holds on the list, either as its owner or
+// through a share.
+func (l *List) RoleFor(userID string) (Role, bool) {
+	if l.OwnerID == userID {
+		return RoleOwner, true
+	}
+
+	for _, share := range l.Shares {
+		if share.UserID == userID {
+			return share.Role, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,39 @@
+package model
+
+type Priority string
+
+const (
+	PriorityNone   Priority = "none"
+	PriorityLow    Priority = "low"
+	PriorityMedium Priority = "medium"
+	PriorityHigh   Priority = "high"
+	PriorityUrgent Priority = "urgent"
+)
+
+var Priorities = []Priority{
+	PriorityNone,
+	PriorityLow,
+	PriorityMedium,
+	PriorityHigh,
+	PriorityUrgent,
+}
+
+func ParsePriority(value string) (Priority, bool) {
+	for _, priority := range Priorities {
+		if string(priority) == value {
+			return priority, true
+		}
+	}
+	return "", false
+}
+
+// Rank orders priorities from none (0) to urgent (4). Unknown values rank
+// as none.
+func (p Priority) Rank() int {
+	for i, priority := range Priorities {
+		if priority == p {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,80 @@
+package model
+
+import "strings"
+
+type SearchQuery struct {
+	Include []string
+	Exclude []string
+}
+
+// ParseSearchQuery splits a search string into terms. Double-quoted text is
+// kept as a single phrase and a leading '-' excludes the term or phrase.
+func ParseSearchQuery(raw string) SearchQuery {
+	var query SearchQuery
+
+	input := []rune(strings.ToLower(raw))
+	for i := 0; i < len(input); {
+		if input[i] == ' ' || input[i] == '\t' {
+			i++
+			continue
+		}
+
+		exclude := false
+		if input[i] == '-' {
+			exclude = true
+			i++
+		}
+
+		var term string
+		if i < len(input) && input[i] == '"' {
+			end := i + 1
+			for end < len(input) && input[end] != '"' {
+				end++
+			}
+			term = string(input[i+1 : end])
+			i = end + 1
+		} else {
+			end := i
+			for end < len(input) && input[end] != ' ' && input[end] != '\t' {
+				end++
+			}
+			term = string(input[i:end])
+			i = end
+		}
+
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		if exclude {
+			query.Exclude = append(query.Exclude, term)
+		} else {
+			query.Include = append(query.Include, term)
+		}
+	}
+
+	return query
+}
+
+func (q SearchQuery) IsEmpty() bool {
+	return len(q.Include) == 0 && len(q.Exclude) == 0
+}
+
+func (q SearchQuery) Matches(task *Task) bool {
+	text := strings.ToLower(task.Title + "\n" + task.Description)
+
+	for _, term := range q.Include {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+
+	for _, term := range q.Exclude {
+		if strings.Contains(text, term) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,74 @@
+package model
+
+import "time"
+
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleOwner  Role = "owner"
+)
+
+var Roles = []Role{
+	RoleViewer,
+	RoleEditor,
+	RoleOwner,
+}
+
+type Share struct {
+	UserID    string
+	Role      Role
+	GrantedBy string
+	CreatedAt time.Time
+}
+
+func ParseRole(value string) (Role, bool) {
+	for _, role := range Roles {
+		if string(role) == value {
+			return role, true
+		}
+	}
+	return "", false
+}
+
+// Allows reports whether r grants at least the permissions of required.
+// Roles are ordered viewer < editor < owner.
+func (r Role) Allows(required Role) bool {
+	return r.rank() >= required.rank()
+}
+
+// Inherited is the role a list role grants on the tasks in the list.
+// Ownership is not inherited, so deleting or sharing a task always needs
+// the task's own owner role.
+func (r Role) Inherited() Role {
+	if r == RoleOwner {
+		return RoleEditor
+	}
+	return r
+}
+
+func (r Role) rank() int {
+	for i, role := range Roles {
+		if role == r {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// RoleFor returns the role userID holds on the task, either as its owner or
+// through a share.
+func (t *Task) RoleFor(userID string) (Role, bool) {
+	if t.OwnerID == userID {
+		return RoleOwner, true
+	}
+
+	for _, share := range t.Shares {
+		if share.UserID == userID {
+			return share.Role, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"slices"
+	"strings"
+)
+
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// NormalizeTags trims and lowercases tags, dropping empty values and
+// duplicates while keeping the original order.
+func NormalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	return normalized
+}
+
+func (t *Task) HasTag(tag string) bool {
+	return slices.Contains(t.Tags, tag)
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{name: "nil stays nil", tags: nil, want: nil},
+		{name: "empty", tags: []string{}, want: []string{}},
+		{name: "lowercased and trimmed", tags: []string{"  Home ", "WORK"}, want: []string{"home", "work"}},
+		{name: "duplicates after normalizing", tags: []string{"home", "Home", " home"}, want: []string{"home"}},
+		{name: "blank tags dropped", tags: []string{"", "   ", "trip"}, want: []string{"trip"}},
+		{name: "first occurrence keeps its place", tags: []string{"b", "a", "B"}, want: []string{"b", "a"}},
+		{name: "inner spaces kept", tags: []string{"Side  Project"}, want: []string{"side  project"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeTags(tt.tags)
+			if (got == nil) != (tt.want == nil) || !slices.Equal(got, tt.want) {
+				t.Errorf("NormalizeTags(%q) = %q, want %q", tt.tags, got, tt.want)
+			}
+		})
+	}
+}
@@ -12,6 +12,21 @@ type Task struct {
 	Completed   bool
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	DueAt       *time.Time
+	Priority    Priority
+	Tags        []string
+	Items       []ChecklistItem
+	ListID      string
+	OwnerID     string
+	Shares      []Share
+	Version     int64
+	DeletedAt   *time.Time
+}
+
+type TaskPage struct {
+	Tasks      []*Task
+	NextCursor string
+	TotalCount int
 }
 
 func NewTask(title, description string) *Task {
@@ -19,6 +34,7 @@ func NewTask(title, description string) *Task {
 		Title:       title,
 		Description: description,
 		Completed:   false,
+		Priority:    PriorityNone,
 	}
 }
 
@@ -42,6 +58,10 @@ func (t *Task) IsCompleted() bool {
 	return t.Completed
 }
 
+func (t *Task) IsOverdue(now time.Time) bool {
+	return !t.Completed && t.DueAt != nil && t.DueAt.Before(now)
+}
+
 func (t *Task) MarkCompleted() {
 	t.Completed = true
 }
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTaskIsOverdue(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Minute)
+	later := now.Add(time.Minute)
+
+	tests := []struct {
+		name string
+		task Task
+		want bool
+	}{
+		{name: "no due date", task: Task{}},
+		{name: "due in the future", task: Task{DueAt: &later}},
+		{name: "due now", task: Task{DueAt: &now}},
+		{name: "past due", task: Task{DueAt: &earlier}, want: true},
+		{name: "past due but completed", task: Task{DueAt: &earlier, Completed: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.task.IsOverdue(now); got != tt.want {
+				t.Errorf("IsOverdue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
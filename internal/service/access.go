@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/auth"
+	"github.com/Raisondetr3/checklist-api-service/internal/client"
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/internal/validator"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+	"github.com/Raisondetr3/checklist-api-service/pkg/logger"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// taskAccess checks the caller's role on a task or list before an operation
+// is sent to db-service. Requests without a principal (authentication
+// disabled) and admin principals are not restricted. Tasks and lists without
+// an owner are only accessible to admins.
+//
+// A role on a list also applies to the tasks in it, except that list owners
+// are editors of the tasks; see model.Role.Inherited.
+type taskAccess struct {
+	grpcClient client.TaskClient
+}
+
+// authorize loads the task and verifies the caller holds at least the
+// required role on it. The task is loaded even when the caller is not
+// restricted, so callers can rely on the returned snapshot.
+func (a taskAccess) authorize(ctx context.Context, taskID string, required model.Role) (*model.Task, error) {
+	protoResp, err := a.grpcClient.GetTask(ctx, dto.GetTaskRequestToProto(taskID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	task := dto.ProtoToModelTask(protoResp.Task)
+	if err := a.checkTask(ctx, task, required); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// authorizeMutation is authorize for operations that change the task.
+// Tasks in the trash must be restored before they can be changed.
+func (a taskAccess) authorizeMutation(ctx context.Context, taskID string, required model.Role) (*model.Task, error) {
+	task, err := a.authorize(ctx, taskID, required)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkNotTrashed(task); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+func checkNotTrashed(task *model.Task) error {
+	if task.DeletedAt != nil {
+		return errTaskInTrash
+	}
+	return nil
+}
+
+func (a taskAccess) checkTask(ctx context.Context, task *model.Task, required model.Role) error {
+	return a.checkTaskWith(ctx, task, required, a.getList)
+}
+
+// checkTaskWith is checkTask with the lookup used for the task's list, so
+// callers checking many tasks can share one cache of lists.
+func (a taskAccess) checkTaskWith(ctx context.Context, task *model.Task, required model.Role, lists listLookup) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.IsAdmin() {
+		return nil
+	}
+
+	var role model.Role
+	if task.OwnerID != "" {
+		if taskRole, ok := task.RoleFor(principal.Subject); ok {
+			role = taskRole
+		}
+		if !role.Allows(required) && task.ListID != "" {
+			if listRole, ok := listRoleFor(ctx, lists, task.ListID, principal.Subject); ok && listRole.Inherited().Allows(required) {
+				role = listRole.Inherited()
+			}
+		}
+		if role.Allows(required) {
+			return nil
+		}
+	}
+
+	err := status.Errorf(codes.PermissionDenied, "%s access to this task is required", required)
+	logger.LogError(ctx, err, "AuthorizeTask",
+		slog.String("task_id", task.ID),
+		slog.String("subject", principal.Subject),
+		slog.String("role", string(role)),
+		slog.String("required_role", string(required)),
+		slog.Bool("ownerless", task.OwnerID == ""),
+	)
+	return err
+}
+
+type listLookup func(ctx context.Context, listID string) (*model.List, error)
+
+func (a taskAccess) getList(ctx context.Context, listID string) (*model.List, error) {
+	protoResp, err := a.grpcClient.GetList(ctx, dto.GetListRequestToProto(listID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list: %w", err)
+	}
+	if protoResp.List == nil {
+		return nil, status.Error(codes.NotFound, "list not found")
+	}
+
+	return dto.ProtoToModelList(protoResp.List), nil
+}
+
+// cachedLists returns a lookup that fetches each list at most once. It is
+// meant for a single request that checks many tasks.
+func (a taskAccess) cachedLists() listLookup {
+	type result struct {
+		list *model.List
+		err  error
+	}
+	cache := make(map[string]result)
+
+	return func(ctx context.Context, listID string) (*model.List, error) {
+		if r, ok := cache[listID]; ok {
+			return r.list, r.err
+		}
+		list, err := a.getList(ctx, listID)
+		cache[listID] = result{list: list, err: err}
+		return list, err
+	}
+}
+
+// listRoleFor returns the caller's role on a list. Lookup failures grant no
+// role, so they can only narrow access.
+func listRoleFor(ctx context.Context, lists listLookup, listID, subject string) (model.Role, bool) {
+	list, err := lists(ctx, listID)
+	if err != nil || list.OwnerID == "" {
+		return "", false
+	}
+
+	return list.RoleFor(subject)
+}
+
+// authorizeList loads the list and verifies the caller holds at least the
+// required role on it.
+func (a taskAccess) authorizeList(ctx context.Context, listID string, required model.Role) (*model.List, error) {
+	return authorizeListWith(ctx, a.getList, listID, required)
+}
+
+func authorizeListWith(ctx context.Context, lists listLookup, listID string, required model.Role) (*model.List, error) {
+	list, err := lists(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkListRole(ctx, list, required); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// authorizeListTarget checks that the caller may add tasks to listID. An
+// empty listID means no list and needs no check.
+func (a taskAccess) authorizeListTarget(ctx context.Context, listID *string) error {
+	return authorizeListTargetWith(ctx, a.getList, listID)
+}
+
+func authorizeListTargetWith(ctx context.Context, lists listLookup, listID *string) error {
+	if listID == nil || *listID == "" {
+		return nil
+	}
+
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.IsAdmin() {
+		return nil
+	}
+
+	_, err := authorizeListWith(ctx, lists, *listID, model.RoleEditor)
+	return err
+}
+
+// authorizeCascade checks that the caller owns every task a cascading list
+// delete would remove, including trashed ones.
+func (a taskAccess) authorizeCascade(ctx context.Context, listID string) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.IsAdmin() {
+		return nil
+	}
+
+	lists := a.cachedLists()
+	for _, deleted := range []bool{false, true} {
+		req := dto.ListTasksRequest{ListID: listID, Limit: validator.MaxPageSize, Deleted: deleted}
+
+		for {
+			protoResp, err := a.grpcClient.ListTasks(ctx, dto.ListTasksRequestToProto(req))
+			if err != nil {
+				return fmt.Errorf("failed to list tasks: %w", err)
+			}
+
+			for _, task := range dto.ProtoToModelTasks(protoResp.Tasks) {
+				if err := a.checkTaskWith(ctx, task, model.RoleOwner, lists); err != nil {
+					return status.Errorf(codes.PermissionDenied, "owner access to every task in the list is required to delete it with cascade=true")
+				}
+			}
+
+			if protoResp.NextPageToken == "" {
+				break
+			}
+			req.Cursor = protoResp.NextPageToken
+		}
+	}
+
+	return nil
+}
+
+func checkListRole(ctx context.Context, list *model.List, required model.Role) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.IsAdmin() {
+		return nil
+	}
+
+	var role model.Role
+	if list.OwnerID != "" {
+		if listRole, ok := list.RoleFor(principal.Subject); ok {
+			role = listRole
+		}
+		if role.Allows(required) {
+			return nil
+		}
+	}
+
+	err := status.Errorf(codes.PermissionDenied, "%s access to this list is required", required)
+	logger.LogError(ctx, err, "AuthorizeList",
+		slog.String("list_id", list.ID),
+		slog.String("subject", principal.Subject),
+		slog.String("role", string(role)),
+		slog.String("required_role", string(required)),
+		slog.Bool("ownerless", list.OwnerID == ""),
+	)
+	return err
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/auth"
+	"github.com/Raisondetr3/checklist-api-service/internal/client"
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	pb "github.com/Raisondetr3/checklist-api-service/pkg/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeTaskClient serves tasks and lists from memory. Methods a test does not
+// stub panic through the embedded nil interface.
+type fakeTaskClient struct {
+	client.TaskClient
+
+	tasks map[string]*pb.Task
+	lists map[string]*pb.List
+
+	getTaskCalls       int
+	getListCalls       int
+	batchGetTasksCalls int
+	mutated            []*pb.BatchOperation
+	itemCalls          []string
+}
+
+func (c *fakeTaskClient) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb.TaskResponse, error) {
+	c.getTaskCalls++
+	task, ok := c.tasks[req.Id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "task not found")
+	}
+	return &pb.TaskResponse{Task: task}, nil
+}
+
+func (c *fakeTaskClient) GetList(ctx context.Context, req *pb.GetListRequest) (*pb.ListResponse, error) {
+	c.getListCalls++
+	list, ok := c.lists[req.Id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "list not found")
+	}
+	return &pb.ListResponse{List: list}, nil
+}
+
+func (c *fakeTaskClient) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	var tasks []*pb.Task
+	for _, task := range c.tasks {
+		if task.ListId == req.ListId && (task.DeletedAt != nil) == req.Deleted {
+			tasks = append(tasks, task)
+		}
+	}
+	return &pb.ListTasksResponse{Tasks: tasks, TotalCount: int32(len(tasks))}, nil
+}
+
+func (c *fakeTaskClient) BatchGetTasks(ctx context.Context, req *pb.BatchGetTasksRequest) (*pb.BatchGetTasksResponse, error) {
+	c.batchGetTasksCalls++
+	var tasks []*pb.Task
+	for _, id := range req.Ids {
+		if task, ok := c.tasks[id]; ok {
+			tasks = append(tasks, task)
+		}
+	}
+	return &pb.BatchGetTasksResponse{Tasks: tasks}, nil
+}
+
+// BatchMutate reports every operation as applied without changing the
+// stored tasks.
+func (c *fakeTaskClient) BatchMutate(ctx context.Context, req *pb.BatchMutateRequest) (*pb.BatchMutateResponse, error) {
+	c.mutated = append(c.mutated, req.Operations...)
+	results := make([]*pb.BatchResult, len(req.Operations))
+	for i, op := range req.Operations {
+		result := &pb.BatchResult{}
+		switch op := op.Operation.(type) {
+		case *pb.BatchOperation_Create:
+			result.Task = &pb.Task{Id: fmt.Sprintf("created-%d", i), Title: op.Create.Title, ListId: op.Create.ListId}
+		case *pb.BatchOperation_Update:
+			result.Task = c.tasks[op.Update.Id]
+		}
+		results[i] = result
+	}
+	return &pb.BatchMutateResponse{Results: results}, nil
+}
+
+func (c *fakeTaskClient) CreateChecklistItem(ctx context.Context, req *pb.CreateChecklistItemRequest) (*pb.ChecklistItemResponse, error) {
+	id := fmt.Sprintf("new-%d", len(c.itemCalls))
+	c.itemCalls = append(c.itemCalls, "create "+req.Text+" as "+id)
+	return &pb.ChecklistItemResponse{Item: &pb.ChecklistItem{Id: id, Text: req.Text, Done: req.Done}}, nil
+}
+
+// UpdateChecklistItem returns the stored item with the request applied,
+// without changing the stored task.
+func (c *fakeTaskClient) UpdateChecklistItem(ctx context.Context, req *pb.UpdateChecklistItemRequest) (*pb.ChecklistItemResponse, error) {
+	c.itemCalls = append(c.itemCalls, "update "+req.ItemId)
+	item := &pb.ChecklistItem{Id: req.ItemId}
+	if task, ok := c.tasks[req.TaskId]; ok {
+		for _, existing := range task.Items {
+			if existing.Id == req.ItemId {
+				item = &pb.ChecklistItem{Id: existing.Id, Text: existing.Text, Done: existing.Done, Position: existing.Position}
+			}
+		}
+	}
+	if req.Text != nil {
+		item.Text = *req.Text
+	}
+	if req.Done != nil {
+		item.Done = *req.Done
+	}
+	return &pb.ChecklistItemResponse{Item: item}, nil
+}
+
+func (c *fakeTaskClient) DeleteChecklistItem(ctx context.Context, req *pb.DeleteChecklistItemRequest) (*pb.DeleteChecklistItemResponse, error) {
+	c.itemCalls = append(c.itemCalls, "delete "+req.ItemId)
+	return &pb.DeleteChecklistItemResponse{Success: true}, nil
+}
+
+func (c *fakeTaskClient) ReorderChecklistItems(ctx context.Context, req *pb.ReorderChecklistItemsRequest) (*pb.ListChecklistItemsResponse, error) {
+	c.itemCalls = append(c.itemCalls, "reorder "+strings.Join(req.ItemIds, ","))
+	return &pb.ListChecklistItemsResponse{}, nil
+}
+
+func (c *fakeTaskClient) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb.TaskResponse, error) {
+	return &pb.TaskResponse{Task: c.tasks[req.Id]}, nil
+}
+
+func (c *fakeTaskClient) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*pb.DeleteTaskResponse, error) {
+	return &pb.DeleteTaskResponse{Success: true}, nil
+}
+
+// DeleteList removes the list's tasks, trashed ones included, when cascade
+// is set.
+func (c *fakeTaskClient) DeleteList(ctx context.Context, req *pb.DeleteListRequest) (*pb.DeleteListResponse, error) {
+	var deleted []*pb.Task
+	for id, task := range c.tasks {
+		if task.ListId != req.Id {
+			continue
+		}
+		if !req.Cascade {
+			return nil, status.Error(codes.FailedPrecondition, "list is not empty")
+		}
+		deleted = append(deleted, task)
+		delete(c.tasks, id)
+	}
+	delete(c.lists, req.Id)
+	return &pb.DeleteListResponse{Success: true, DeletedTasks: int32(len(deleted)), Tasks: deleted}, nil
+}
+
+func (c *fakeTaskClient) PurgeDeletedTasks(ctx context.Context, req *pb.PurgeDeletedTasksRequest) (*pb.PurgeDeletedTasksResponse, error) {
+	var purged []*pb.Task
+	for id, task := range c.tasks {
+		if task.DeletedAt != nil && task.DeletedAt.AsTime().Before(req.DeletedBefore.AsTime()) {
+			purged = append(purged, task)
+			delete(c.tasks, id)
+		}
+	}
+	return &pb.PurgeDeletedTasksResponse{Purged: int32(len(purged)), Tasks: purged}, nil
+}
+
+func (c *fakeTaskClient) ShareTask(ctx context.Context, req *pb.ShareTaskRequest) (*pb.ShareResponse, error) {
+	return &pb.ShareResponse{Share: &pb.Share{UserId: req.UserId, Role: req.Role}}, nil
+}
+
+func newAccessFixture() *fakeTaskClient {
+	return &fakeTaskClient{
+		tasks: map[string]*pb.Task{
+			"own":       {Id: "own", OwnerId: "alice"},
+			"shared":    {Id: "shared", OwnerId: "bob", Shares: []*pb.Share{{UserId: "alice", Role: pb.ShareRole_SHARE_ROLE_VIEWER}}},
+			"ownerless": {Id: "ownerless"},
+			"in-list":   {Id: "in-list", OwnerId: "bob", ListId: "groceries"},
+			"in-shared": {Id: "in-shared", OwnerId: "bob", ListId: "team"},
+		},
+		lists: map[string]*pb.List{
+			"groceries": {Id: "groceries", OwnerId: "alice"},
+			"team":      {Id: "team", OwnerId: "bob", Shares: []*pb.Share{{UserId: "alice", Role: pb.ShareRole_SHARE_ROLE_VIEWER}}},
+			"orphan":    {Id: "orphan"},
+		},
+	}
+}
+
+func withSubject(subject string, scopes ...string) context.Context {
+	if len(scopes) == 0 {
+		scopes = auth.DefaultScopes
+	}
+	return auth.WithPrincipal(context.Background(), auth.Principal{Subject: subject, Scopes: scopes})
+}
+
+func TestTaskAccessAuthorize(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		taskID   string
+		required model.Role
+		wantCode codes.Code
+	}{
+		{"owner", withSubject("alice"), "own", model.RoleOwner, codes.OK},
+		{"stranger", withSubject("carol"), "own", model.RoleViewer, codes.PermissionDenied},
+		{"shared viewer reads", withSubject("alice"), "shared", model.RoleViewer, codes.OK},
+		{"shared viewer cannot edit", withSubject("alice"), "shared", model.RoleEditor, codes.PermissionDenied},
+		{"ownerless task denied", withSubject("alice"), "ownerless", model.RoleViewer, codes.PermissionDenied},
+		{"ownerless task allowed for admin", withSubject("root", auth.ScopeAdmin), "ownerless", model.RoleOwner, codes.OK},
+		{"list owner edits task", withSubject("alice"), "in-list", model.RoleEditor, codes.OK},
+		{"list owner does not own task", withSubject("alice"), "in-list", model.RoleOwner, codes.PermissionDenied},
+		{"list viewer reads task", withSubject("alice"), "in-shared", model.RoleViewer, codes.OK},
+		{"list viewer cannot edit task", withSubject("alice"), "in-shared", model.RoleEditor, codes.PermissionDenied},
+		{"missing task", withSubject("alice"), "missing", model.RoleViewer, codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			access := taskAccess{grpcClient: newAccessFixture()}
+
+			_, err := access.authorize(tt.ctx, tt.taskID, tt.required)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("authorize() code = %v, want %v (err: %v)", got, tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestTaskAccessAuthorizeList(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		listID   string
+		required model.Role
+		wantCode codes.Code
+	}{
+		{"owner", withSubject("alice"), "groceries", model.RoleOwner, codes.OK},
+		{"shared viewer reads", withSubject("alice"), "team", model.RoleViewer, codes.OK},
+		{"shared viewer cannot edit", withSubject("alice"), "team", model.RoleEditor, codes.PermissionDenied},
+		{"stranger", withSubject("carol"), "groceries", model.RoleViewer, codes.PermissionDenied},
+		{"ownerless list denied", withSubject("alice"), "orphan", model.RoleViewer, codes.PermissionDenied},
+		{"ownerless list allowed for admin", withSubject("root", auth.ScopeAdmin), "orphan", model.RoleOwner, codes.OK},
+		{"no principal", context.Background(), "orphan", model.RoleOwner, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			access := taskAccess{grpcClient: newAccessFixture()}
+
+			_, err := access.authorizeList(tt.ctx, tt.listID, tt.required)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("authorizeList() code = %v, want %v (err: %v)", got, tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestTaskAccessAuthorizeCascade(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		tasks    map[string]*pb.Task
+		wantCode codes.Code
+	}{
+		{
+			name: "caller owns every task",
+			ctx:  withSubject("alice"),
+			tasks: map[string]*pb.Task{
+				"a": {Id: "a", OwnerId: "alice", ListId: "groceries"},
+				"b": {Id: "b", OwnerId: "alice", ListId: "groceries"},
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "someone else's task",
+			ctx:  withSubject("alice"),
+			tasks: map[string]*pb.Task{
+				"a": {Id: "a", OwnerId: "alice", ListId: "groceries"},
+				"b": {Id: "b", OwnerId: "bob", ListId: "groceries"},
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "someone else's trashed task",
+			ctx:  withSubject("alice"),
+			tasks: map[string]*pb.Task{
+				"b": {Id: "b", OwnerId: "bob", ListId: "groceries", DeletedAt: timestamppb.Now()},
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "ownerless task",
+			ctx:  withSubject("alice"),
+			tasks: map[string]*pb.Task{
+				"a": {Id: "a", ListId: "groceries"},
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "admin",
+			ctx:  withSubject("root", auth.ScopeAdmin),
+			tasks: map[string]*pb.Task{
+				"b": {Id: "b", OwnerId: "bob", ListId: "groceries"},
+			},
+			wantCode: codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newAccessFixture()
+			fake.tasks = tt.tasks
+			access := taskAccess{grpcClient: fake}
+
+			err := access.authorizeCascade(tt.ctx, "groceries")
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("authorizeCascade() code = %v, want %v (err: %v)", got, tt.wantCode, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/client"
+	"github.com/Raisondetr3/checklist-api-service/internal/history"
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+	"github.com/Raisondetr3/checklist-api-service/pkg/logger"
+)
+
+type ChecklistItemService interface {
+	ListItems(ctx context.Context, taskID string) ([]model.ChecklistItem, error)
+	GetItem(ctx context.Context, taskID, itemID string) (*model.ChecklistItem, error)
+	CreateItem(ctx context.Context, taskID string, req dto.CreateChecklistItemRequest) (*model.ChecklistItem, error)
+	UpdateItem(ctx context.Context, taskID, itemID string, req dto.UpdateChecklistItemRequest) (*model.ChecklistItem, error)
+	DeleteItem(ctx context.Context, taskID, itemID string) error
+	ReorderItems(ctx context.Context, taskID string, itemIDs []string) ([]model.ChecklistItem, error)
+}
+
+type checklistItemService struct {
+	grpcClient client.TaskClient
+	access     taskAccess
+	history    history.Store
+}
+
+func NewChecklistItemService(taskClient client.TaskClient, historyStore history.Store) ChecklistItemService {
+	return &checklistItemService{
+		grpcClient: taskClient,
+		access:     taskAccess{grpcClient: taskClient},
+		history:    historyStore,
+	}
+}
+
+func (s *checklistItemService) ListItems(ctx context.Context, taskID string) ([]model.ChecklistItem, error) {
+	start := time.Now()
+	operation := "ListChecklistItems"
+
+	if _, err := s.access.authorize(ctx, taskID, model.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	protoResp, err := s.grpcClient.ListChecklistItems(ctx, dto.ListChecklistItemsRequestToProto(taskID))
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.String("task_id", taskID),
+		)
+		return nil, fmt.Errorf("failed to list checklist items: %w", err)
+	}
+
+	items := dto.ProtoToChecklistItems(protoResp.Items)
+
+	slog.InfoContext(ctx, "Checklist items retrieved successfully",
+		slog.String("operation", operation),
+		slog.String("task_id", taskID),
+		slog.Int("count", len(items)),
+		slog.Duration("duration", duration),
+	)
+
+	return items, nil
+}
+
+func (s *checklistItemService) GetItem(ctx context.Context, taskID, itemID string) (*model.ChecklistItem, error) {
+	start := time.Now()
+	operation := "GetChecklistItem"
+
+	if _, err := s.access.authorize(ctx, taskID, model.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	protoResp, err := s.grpcClient.GetChecklistItem(ctx, dto.GetChecklistItemRequestToProto(taskID, itemID))
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.String("task_id", taskID),
+			slog.String("item_id", itemID),
+		)
+		return nil, fmt.Errorf("failed to get checklist item: %w", err)
+	}
+
+	item := dto.ProtoToChecklistItem(protoResp.Item)
+
+	return &item, nil
+}
+
+func (s *checklistItemService) CreateItem(ctx context.Context, taskID string, req dto.CreateChecklistItemRequest) (*model.ChecklistItem, error) {
+	start := time.Now()
+	operation := "CreateChecklistItem"
+
+	task, err := s.access.authorizeMutation(ctx, taskID, model.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	protoResp, err := s.grpcClient.CreateChecklistItem(ctx, dto.CreateChecklistItemRequestToProto(taskID, req))
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.String("task_id", taskID),
+		)
+		return nil, fmt.Errorf("failed to create checklist item: %w", err)
+	}
+
+	item := dto.ProtoToChecklistItem(protoResp.Item)
+
+	slog.InfoContext(ctx, "Checklist item created successfully",
+		slog.String("operation", operation),
+		slog.String("task_id", taskID),
+		slog.String("item_id", item.ID),
+		slog.Duration("duration", duration),
+	)
+
+	s.recordItemRevision(ctx, task, append(slices.Clone(task.Items), item))
+
+	return &item, nil
+}
+
+func (s *checklistItemService) UpdateItem(ctx context.Context, taskID, itemID string, req dto.UpdateChecklistItemRequest) (*model.ChecklistItem, error) {
+	start := time.Now()
+	operation := "UpdateChecklistItem"
+
+	task, err := s.access.authorizeMutation(ctx, taskID, model.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	protoResp, err := s.grpcClient.UpdateChecklistItem(ctx, dto.UpdateChecklistItemRequestToProto(taskID, itemID, req))
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.String("task_id", taskID),
+			slog.String("item_id", itemID),
+		)
+		return nil, fmt.Errorf("failed to update checklist item: %w", err)
+	}
+
+	item := dto.ProtoToChecklistItem(protoResp.Item)
+
+	slog.InfoContext(ctx, "Checklist item updated successfully",
+		slog.String("operation", operation),
+		slog.String("task_id", taskID),
+		slog.String("item_id", item.ID),
+		slog.Duration("duration", duration),
+	)
+
+	items := slices.Clone(task.Items)
+	if i := slices.IndexFunc(items, func(existing model.ChecklistItem) bool { return existing.ID == item.ID }); i >= 0 {
+		items[i] = item
+	}
+	s.recordItemRevision(ctx, task, items)
+
+	return &item, nil
+}
+
+func (s *checklistItemService) DeleteItem(ctx context.Context, taskID, itemID string) error {
+	start := time.Now()
+	operation := "DeleteChecklistItem"
+
+	task, err := s.access.authorizeMutation(ctx, taskID, model.RoleEditor)
+	if err != nil {
+		return err
+	}
+
+	protoResp, err := s.grpcClient.DeleteChecklistItem(ctx, dto.DeleteChecklistItemRequestToProto(taskID, itemID))
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.String("task_id", taskID),
+			slog.String("item_id", itemID),
+		)
+		return fmt.Errorf("failed to delete checklist item: %w", err)
+	}
+
+	if !protoResp.Success {
+		err := fmt.Errorf("checklist item deletion was not successful")
+		logger.LogError(ctx, err, operation,
+			slog.String("task_id", taskID),
+			slog.String("item_id", itemID),
+		)
+		return err
+	}
+
+	slog.InfoContext(ctx, "Checklist item deleted successfully",
+		slog.String("operation", operation),
+		slog.String("task_id", taskID),
+		slog.String("item_id", itemID),
+		slog.Duration("duration", duration),
+	)
+
+	s.recordItemRevision(ctx, task, slices.DeleteFunc(slices.Clone(task.Items), func(item model.ChecklistItem) bool {
+		return item.ID == itemID
+	}))
+
+	return nil
+}
+
+func (s *checklistItemService) ReorderItems(ctx context.Context, taskID string, itemIDs []string) ([]model.ChecklistItem, error) {
+	start := time.Now()
+	operation := "ReorderChecklistItems"
+
+	task, err := s.access.authorizeMutation(ctx, taskID, model.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	protoReq := dto.ReorderChecklistItemsRequestToProto(taskID, dto.ReorderChecklistItemsRequest{ItemIDs: itemIDs})
+
+	protoResp, err := s.grpcClient.ReorderChecklistItems(ctx, protoReq)
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.String("task_id", taskID),
+		)
+		return nil, fmt.Errorf("failed to reorder checklist items: %w", err)
+	}
+
+	items := dto.ProtoToChecklistItems(protoResp.Items)
+
+	slog.InfoContext(ctx, "Checklist items reordered successfully",
+		slog.String("operation", operation),
+		slog.String("task_id", taskID),
+		slog.Int("count", len(items)),
+		slog.Duration("duration", duration),
+	)
+
+	s.recordItemRevision(ctx, task, items)
+
+	return items, nil
+}
+
+// recordItemRevision records an item change as an update of its task. Item
+// RPCs do not return the task, so the new state is the snapshot loaded for
+// the access check with its items replaced.
+func (s *checklistItemService) recordItemRevision(ctx context.Context, before *model.Task, items []model.ChecklistItem) {
+	after := *before
+	after.Items = items
+	appendRevision(ctx, s.history, revisionActor(ctx), history.OperationUpdate, before, &after)
+}
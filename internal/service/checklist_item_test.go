@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/history"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+	pb "github.com/Raisondetr3/checklist-api-service/pkg/pb"
+)
+
+func TestChecklistItemChangesAreRecorded(t *testing.T) {
+	done := true
+
+	tests := []struct {
+		name string
+		call func(ctx context.Context, svc ChecklistItemService) error
+		want []history.FieldChange
+	}{
+		{
+			name: "tick off",
+			call: func(ctx context.Context, svc ChecklistItemService) error {
+				_, err := svc.UpdateItem(ctx, "own", "item-1", dto.UpdateChecklistItemRequest{Done: &done})
+				return err
+			},
+			want: []history.FieldChange{{Field: "items.item-1.done", Before: false, After: true}},
+		},
+		{
+			name: "add",
+			call: func(ctx context.Context, svc ChecklistItemService) error {
+				_, err := svc.CreateItem(ctx, "own", dto.CreateChecklistItemRequest{Text: "Map"})
+				return err
+			},
+			want: []history.FieldChange{
+				{Field: "items.new-0.text", Before: nil, After: "Map"},
+				{Field: "items.new-0.done", Before: nil, After: false},
+				{Field: "items.new-0.position", Before: nil, After: 0},
+			},
+		},
+		{
+			name: "remove",
+			call: func(ctx context.Context, svc ChecklistItemService) error {
+				return svc.DeleteItem(ctx, "own", "item-1")
+			},
+			want: []history.FieldChange{
+				{Field: "items.item-1.text", Before: "Passport", After: nil},
+				{Field: "items.item-1.done", Before: false, After: nil},
+				{Field: "items.item-1.position", Before: 0, After: nil},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newAccessFixture()
+			fake.tasks["own"].Items = []*pb.ChecklistItem{{Id: "item-1", Text: "Passport"}}
+			store := history.NewMemoryStore(10, time.Hour)
+
+			if err := tt.call(withSubject("alice"), NewChecklistItemService(fake, store)); err != nil {
+				t.Fatalf("call error = %v", err)
+			}
+
+			revisions, _ := store.List(context.Background(), "own")
+			if len(revisions) != 1 {
+				t.Fatalf("revisions = %+v, want one", revisions)
+			}
+			if revisions[0].Actor != "alice" || revisions[0].Operation != history.OperationUpdate {
+				t.Errorf("revision = %+v, want an update by alice", revisions[0])
+			}
+			if !reflect.DeepEqual(revisions[0].Changes, tt.want) {
+				t.Errorf("changes = %+v, want %+v", revisions[0].Changes, tt.want)
+			}
+		})
+	}
+}
@@ -16,21 +16,38 @@ type HealthService interface {
 	CheckHealth(ctx context.Context) (*model.Health, error)
 }
 
+type QueueDepthReporter interface {
+	Depth() int
+}
+
 type healthService struct {
 	config     *config.Config
 	httpClient *http.Client
+	outbox     QueueDepthReporter
 }
 
-func NewHealthService(cfg *config.Config) HealthService {
+func NewHealthService(cfg *config.Config, outbox QueueDepthReporter) HealthService {
 	return &healthService{
 		config: cfg,
 		httpClient: &http.Client{
 			Timeout: cfg.ExternalServices.DBService.Timeout,
 		},
+		outbox: outbox,
 	}
 }
 
 func (s *healthService) CheckHealth(ctx context.Context) (*model.Health, error) {
+	health, err := s.checkDBService(ctx)
+
+	if s.outbox != nil {
+		depth := s.outbox.Depth()
+		health.OutboxDepth = &depth
+	}
+
+	return health, err
+}
+
+func (s *healthService) checkDBService(ctx context.Context) (*model.Health, error) {
 	dbURL := fmt.Sprintf("%s/health", s.config.ExternalServices.DBService.HTTPUrl)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", dbURL, nil)
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/client"
+	"github.com/Raisondetr3/checklist-api-service/internal/events"
+	"github.com/Raisondetr3/checklist-api-service/internal/history"
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+	apiErrors "github.com/Raisondetr3/checklist-api-service/pkg/errors"
+	"github.com/Raisondetr3/checklist-api-service/pkg/logger"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ListService interface {
+	CreateList(ctx context.Context, req dto.CreateListRequest) (*model.List, error)
+	GetLists(ctx context.Context, req dto.ListListsRequest) (*model.ListPage, error)
+	GetList(ctx context.Context, listID string) (*model.List, error)
+	UpdateList(ctx context.Context, listID string, req dto.UpdateListRequest) (*model.List, error)
+	DeleteList(ctx context.Context, listID string, cascade bool) (int, error)
+	GetListTasks(ctx context.Context, listID string, req dto.ListTasksRequest) (*model.TaskPage, error)
+}
+
+type listService struct {
+	grpcClient  client.TaskClient
+	taskService TaskService
+	publisher   events.Publisher
+	history     history.Store
+	access      taskAccess
+}
+
+func NewListService(taskClient client.TaskClient, taskService TaskService, publisher events.Publisher, historyStore history.Store) ListService {
+	return &listService{
+		grpcClient:  taskClient,
+		taskService: taskService,
+		publisher:   publisher,
+		history:     historyStore,
+		access:      taskAccess{grpcClient: taskClient},
+	}
+}
+
+func (s *listService) CreateList(ctx context.Context, req dto.CreateListRequest) (*model.List, error) {
+	start := time.Now()
+	operation := "CreateList"
+
+	protoResp, err := s.grpcClient.CreateList(ctx, dto.CreateListRequestToProto(req))
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.String("name", req.Name),
+		)
+		return nil, fmt.Errorf("failed to create list: %w", err)
+	}
+
+	list := dto.ProtoToModelList(protoResp.List)
+
+	slog.InfoContext(ctx, "List created successfully",
+		slog.String("operation", operation),
+		slog.String("list_id", list.ID),
+		slog.String("name", list.Name),
+		slog.Duration("duration", duration),
+	)
+
+	return list, nil
+}
+
+func (s *listService) GetLists(ctx context.Context, req dto.ListListsRequest) (*model.ListPage, error) {
+	start := time.Now()
+	operation := "GetLists"
+
+	protoResp, err := s.grpcClient.ListLists(ctx, dto.ListListsRequestToProto(req))
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+		)
+		return nil, fmt.Errorf("failed to get lists: %w", err)
+	}
+
+	page := &model.ListPage{
+		Lists:      dto.ProtoToModelLists(protoResp.Lists),
+		NextCursor: protoResp.NextPageToken,
+		TotalCount: int(protoResp.TotalCount),
+	}
+
+	slog.InfoContext(ctx, "Lists retrieved successfully",
+		slog.String("operation", operation),
+		slog.Int("page_count", len(page.Lists)),
+		slog.Int("total_count", page.TotalCount),
+		slog.Duration("duration", duration),
+	)
+
+	return page, nil
+}
+
+func (s *listService) GetList(ctx context.Context, listID string) (*model.List, error) {
+	start := time.Now()
+	operation := "GetList"
+
+	protoResp, err := s.grpcClient.GetList(ctx, dto.GetListRequestToProto(listID))
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.String("list_id", listID),
+		)
+		return nil, fmt.Errorf("failed to get list: %w", err)
+	}
+
+	list := dto.ProtoToModelList(protoResp.List)
+	if err := checkListRole(ctx, list, model.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (s *listService) UpdateList(ctx context.Context, listID string, req dto.UpdateListRequest) (*model.List, error) {
+	start := time.Now()
+	operation := "UpdateList"
+
+	if _, err := s.access.authorizeList(ctx, listID, model.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	protoResp, err := s.grpcClient.UpdateList(ctx, dto.UpdateListRequestToProto(listID, req))
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.String("list_id", listID),
+		)
+		return nil, fmt.Errorf("failed to update list: %w", err)
+	}
+
+	list := dto.ProtoToModelList(protoResp.List)
+
+	slog.InfoContext(ctx, "List updated successfully",
+		slog.String("operation", operation),
+		slog.String("list_id", listID),
+		slog.Duration("duration", duration),
+	)
+
+	return list, nil
+}
+
+// DeleteList removes a list. Without cascade the list must be empty, which
+// db-service checks in the same transaction as the delete; with cascade its
+// tasks are deleted along with it, and the caller must own each of them.
+// Each deleted task is published and recorded like a permanent task delete.
+// It returns the number of deleted tasks.
+func (s *listService) DeleteList(ctx context.Context, listID string, cascade bool) (int, error) {
+	start := time.Now()
+	operation := "DeleteList"
+
+	if _, err := s.access.authorizeList(ctx, listID, model.RoleOwner); err != nil {
+		return 0, err
+	}
+
+	if cascade {
+		if err := s.access.authorizeCascade(ctx, listID); err != nil {
+			logger.LogError(ctx, err, operation,
+				slog.String("list_id", listID),
+				slog.Bool("cascade", cascade),
+			)
+			return 0, err
+		}
+	}
+
+	protoResp, err := s.grpcClient.DeleteList(ctx, dto.DeleteListRequestToProto(listID, cascade))
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.String("list_id", listID),
+			slog.Bool("cascade", cascade),
+		)
+		if !cascade && status.Code(err) == codes.FailedPrecondition {
+			return 0, apiErrors.ErrListNotEmpty
+		}
+		return 0, fmt.Errorf("failed to delete list: %w", err)
+	}
+
+	if !protoResp.Success {
+		err := fmt.Errorf("list deletion was not successful")
+		logger.LogError(ctx, err, operation,
+			slog.String("list_id", listID),
+		)
+		return 0, err
+	}
+
+	slog.InfoContext(ctx, "List deleted successfully",
+		slog.String("operation", operation),
+		slog.String("list_id", listID),
+		slog.Bool("cascade", cascade),
+		slog.Int("deleted_tasks", int(protoResp.DeletedTasks)),
+		slog.Duration("duration", duration),
+	)
+
+	for _, task := range dto.ProtoToModelTasks(protoResp.Tasks) {
+		publishTaskEvent(ctx, s.publisher, dto.EventTaskDeleted, task)
+		appendRevision(ctx, s.history, revisionActor(ctx), history.OperationPurge, task, nil)
+	}
+
+	return int(protoResp.DeletedTasks), nil
+}
+
+func (s *listService) GetListTasks(ctx context.Context, listID string, req dto.ListTasksRequest) (*model.TaskPage, error) {
+	if _, err := s.GetList(ctx, listID); err != nil {
+		return nil, err
+	}
+
+	req.ListID = listID
+	return s.taskService.GetTasks(ctx, req)
+}
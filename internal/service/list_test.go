@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/events"
+	"github.com/Raisondetr3/checklist-api-service/internal/history"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+	apiErrors "github.com/Raisondetr3/checklist-api-service/pkg/errors"
+	pb "github.com/Raisondetr3/checklist-api-service/pkg/pb"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDeleteListRecordsCascadedTasks(t *testing.T) {
+	fake := newAccessFixture()
+	fake.tasks["packed"] = &pb.Task{Id: "packed", Title: "Pack", OwnerId: "alice", ListId: "groceries", Version: 2}
+	fake.tasks["trashed"] = &pb.Task{Id: "trashed", Title: "Unpack", OwnerId: "alice", ListId: "groceries", DeletedAt: timestamppb.Now()}
+	delete(fake.tasks, "in-list")
+
+	publisher := events.NewMemoryPublisher()
+	store := history.NewMemoryStore(10, time.Hour)
+	svc := NewListService(fake, nil, publisher, store)
+
+	deleted, err := svc.DeleteList(withSubject("alice"), "groceries", true)
+	if err != nil {
+		t.Fatalf("DeleteList() error = %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("deleted = %d, want 2", deleted)
+	}
+
+	published := make(map[string]string)
+	for _, event := range publisher.Events() {
+		published[event.TaskID] = event.Type
+	}
+
+	for _, taskID := range []string{"packed", "trashed"} {
+		if published[taskID] != dto.EventTaskDeleted {
+			t.Errorf("event for %s = %q, want %q", taskID, published[taskID], dto.EventTaskDeleted)
+		}
+
+		revisions, _ := store.List(context.Background(), taskID)
+		if len(revisions) != 1 || revisions[0].Operation != history.OperationPurge || revisions[0].Actor != "alice" {
+			t.Errorf("revisions of %s = %+v, want one purge by alice", taskID, revisions)
+		}
+	}
+}
+
+func TestDeleteListWithoutCascade(t *testing.T) {
+	tests := []struct {
+		name    string
+		listID  string
+		wantErr error
+	}{
+		{name: "empty list", listID: "groceries"},
+		{name: "list with tasks", listID: "groceries", wantErr: apiErrors.ErrListNotEmpty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newAccessFixture()
+			delete(fake.tasks, "in-list")
+			if tt.wantErr != nil {
+				fake.tasks["packed"] = &pb.Task{Id: "packed", OwnerId: "alice", ListId: tt.listID}
+			}
+			svc := NewListService(fake, nil, events.NewNopPublisher(), history.NewMemoryStore(10, time.Hour))
+
+			deleted, err := svc.DeleteList(withSubject("alice"), tt.listID, false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DeleteList() error = %v, want %v", err, tt.wantErr)
+			}
+			if deleted != 0 {
+				t.Errorf("deleted = %d, want 0", deleted)
+			}
+			if _, kept := fake.lists[tt.listID]; kept != (tt.wantErr != nil) {
+				t.Errorf("list kept = %v, want %v", kept, tt.wantErr != nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/auth"
+	"github.com/Raisondetr3/checklist-api-service/internal/client"
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+	"github.com/Raisondetr3/checklist-api-service/pkg/logger"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ShareService interface {
+	ListShares(ctx context.Context, taskID string) (string, []model.Share, error)
+	ShareTask(ctx context.Context, taskID string, req dto.ShareTaskRequest) (*model.Share, error)
+	UnshareTask(ctx context.Context, taskID, userID string) error
+	ListListShares(ctx context.Context, listID string) (string, []model.Share, error)
+	ShareList(ctx context.Context, listID string, req dto.ShareTaskRequest) (*model.Share, error)
+	UnshareList(ctx context.Context, listID, userID string) error
+}
+
+type shareService struct {
+	grpcClient client.TaskClient
+	access     taskAccess
+}
+
+func NewShareService(taskClient client.TaskClient) ShareService {
+	return &shareService{
+		grpcClient: taskClient,
+		access:     taskAccess{grpcClient: taskClient},
+	}
+}
+
+// ListShares returns the task owner and the users the task is shared with.
+func (s *shareService) ListShares(ctx context.Context, taskID string) (string, []model.Share, error) {
+	start := time.Now()
+	operation := "ListShares"
+
+	protoTask, err := s.grpcClient.GetTask(ctx, dto.GetTaskRequestToProto(taskID))
+	if err != nil {
+		logger.LogError(ctx, err, operation, slog.String("task_id", taskID))
+		return "", nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	task := dto.ProtoToModelTask(protoTask.Task)
+	if err := s.access.checkTask(ctx, task, model.RoleViewer); err != nil {
+		return "", nil, err
+	}
+
+	protoResp, err := s.grpcClient.ListShares(ctx, dto.ListSharesRequestToProto(taskID))
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.String("task_id", taskID),
+		)
+		return "", nil, fmt.Errorf("failed to list shares: %w", err)
+	}
+
+	shares := dto.ProtoToShares(protoResp.Shares)
+
+	slog.InfoContext(ctx, "Task shares retrieved successfully",
+		slog.String("operation", operation),
+		slog.String("task_id", taskID),
+		slog.Int("count", len(shares)),
+		slog.Duration("duration", duration),
+	)
+
+	return task.OwnerID, shares, nil
+}
+
+func (s *shareService) ShareTask(ctx context.Context, taskID string, req dto.ShareTaskRequest) (*model.Share, error) {
+	start := time.Now()
+	operation := "ShareTask"
+
+	task, err := s.access.authorizeMutation(ctx, taskID, model.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	if task.OwnerID == req.UserID {
+		return nil, status.Error(codes.InvalidArgument, "the task owner cannot be given a share")
+	}
+
+	protoResp, err := s.grpcClient.ShareTask(ctx, dto.ShareTaskRequestToProto(taskID, req))
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.String("task_id", taskID),
+			slog.String("user_id", req.UserID),
+		)
+		return nil, fmt.Errorf("failed to share task: %w", err)
+	}
+
+	share := dto.ProtoToShare(protoResp.Share)
+
+	slog.InfoContext(ctx, "Task shared successfully",
+		slog.String("operation", operation),
+		slog.String("task_id", taskID),
+		slog.String("user_id", share.UserID),
+		slog.String("role", string(share.Role)),
+		slog.Duration("duration", duration),
+	)
+
+	return &share, nil
+}
+
+// UnshareTask revokes a user's share. Owners may revoke any share; other
+// users may only remove their own.
+func (s *shareService) UnshareTask(ctx context.Context, taskID, userID string) error {
+	start := time.Now()
+	operation := "UnshareTask"
+
+	required := model.RoleOwner
+	if auth.SubjectFromContext(ctx) == userID {
+		required = model.RoleViewer
+	}
+
+	if _, err := s.access.authorizeMutation(ctx, taskID, required); err != nil {
+		return err
+	}
+
+	protoResp, err := s.grpcClient.UnshareTask(ctx, dto.UnshareTaskRequestToProto(taskID, userID))
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.String("task_id", taskID),
+			slog.String("user_id", userID),
+		)
+		return fmt.Errorf("failed to unshare task: %w", err)
+	}
+
+	if !protoResp.Success {
+		err := fmt.Errorf("task unshare was not successful")
+		logger.LogError(ctx, err, operation,
+			slog.String("task_id", taskID),
+			slog.String("user_id", userID),
+		)
+		return err
+	}
+
+	slog.InfoContext(ctx, "Task unshared successfully",
+		slog.String("operation", operation),
+		slog.String("task_id", taskID),
+		slog.String("user_id", userID),
+		slog.Duration("duration", duration),
+	)
+
+	return nil
+}
+
+// ListListShares returns the list owner and the users the list is shared
+// with.
+func (s *shareService) ListListShares(ctx context.Context, listID string) (string, []model.Share, error) {
+	start := time.Now()
+	operation := "ListListShares"
+
+	list, err := s.access.authorizeList(ctx, listID, model.RoleViewer)
+	if err != nil {
+		return "", nil, err
+	}
+
+	protoResp, err := s.grpcClient.ListListShares(ctx, dto.ListListSharesRequestToProto(listID))
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.String("list_id", listID),
+		)
+		return "", nil, fmt.Errorf("failed to list list shares: %w", err)
+	}
+
+	shares := dto.ProtoToShares(protoResp.Shares)
+
+	slog.InfoContext(ctx, "List shares retrieved successfully",
+		slog.String("operation", operation),
+		slog.String("list_id", listID),
+		slog.Int("count", len(shares)),
+		slog.Duration("duration", duration),
+	)
+
+	return list.OwnerID, shares, nil
+}
+
+func (s *shareService) ShareList(ctx context.Context, listID string, req dto.ShareTaskRequest) (*model.Share, error) {
+	start := time.Now()
+	operation := "ShareList"
+
+	list, err := s.access.authorizeList(ctx, listID, model.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	if list.OwnerID == req.UserID {
+		return nil, status.Error(codes.InvalidArgument, "the list owner cannot be given a share")
+	}
+
+	protoResp, err := s.grpcClient.ShareList(ctx, dto.ShareListRequestToProto(listID, req))
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.String("list_id", listID),
+			slog.String("user_id", req.UserID),
+		)
+		return nil, fmt.Errorf("failed to share list: %w", err)
+	}
+
+	share := dto.ProtoToShare(protoResp.Share)
+
+	slog.InfoContext(ctx, "List shared successfully",
+		slog.String("operation", operation),
+		slog.String("list_id", listID),
+		slog.String("user_id", share.UserID),
+		slog.String("role", string(share.Role)),
+		slog.Duration("duration", duration),
+	)
+
+	return &share, nil
+}
+
+// UnshareList revokes a user's list share. Owners may revoke any share;
+// other users may only remove their own.
+func (s *shareService) UnshareList(ctx context.Context, listID, userID string) error {
+	start := time.Now()
+	operation := "UnshareList"
+
+	required := model.RoleOwner
+	if auth.SubjectFromContext(ctx) == userID {
+		required = model.RoleViewer
+	}
+
+	if _, err := s.access.authorizeList(ctx, listID, required); err != nil {
+		return err
+	}
+
+	protoResp, err := s.grpcClient.UnshareList(ctx, dto.UnshareListRequestToProto(listID, userID))
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.String("list_id", listID),
+			slog.String("user_id", userID),
+		)
+		return fmt.Errorf("failed to unshare list: %w", err)
+	}
+
+	if !protoResp.Success {
+		err := fmt.Errorf("list unshare was not successful")
+		logger.LogError(ctx, err, operation,
+			slog.String("list_id", listID),
+			slog.String("user_id", userID),
+		)
+		return err
+	}
+
+	slog.InfoContext(ctx, "List unshared successfully",
+		slog.String("operation", operation),
+		slog.String("list_id", listID),
+		slog.String("user_id", userID),
+		slog.Duration("duration", duration),
+	)
+
+	return nil
+}
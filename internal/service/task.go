@@ -7,6 +7,10 @@ import (
 	"time"
 
 	"github.com/Raisondetr3/checklist-api-service/internal/client"
+	"github.com/Raisondetr3/checklist-api-service/internal/config"
+	"github.com/Raisondetr3/checklist-api-service/internal/events"
+	"github.com/Raisondetr3/checklist-api-service/internal/history"
+	"github.com/Raisondetr3/checklist-api-service/internal/jsonpatch"
 	"github.com/Raisondetr3/checklist-api-service/internal/model"
 	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
 	"github.com/Raisondetr3/checklist-api-service/pkg/logger"
@@ -14,19 +18,36 @@ import (
 
 type TaskService interface {
 	CreateTask(ctx context.Context, task *model.Task) (*model.Task, error)
-	GetTasks(ctx context.Context, completed *bool) ([]*model.Task, int, error)
+	GetTasks(ctx context.Context, req dto.ListTasksRequest) (*model.TaskPage, error)
+	ExportTasks(ctx context.Context, req dto.ListTasksRequest, write func([]*model.Task) error) error
 	GetTask(ctx context.Context, taskID string) (*model.Task, error)
-	UpdateTask(ctx context.Context, taskID string, title, description *string, completed *bool) (*model.Task, error)
-	DeleteTask(ctx context.Context, taskID string) error
+	GetTaskIncludingTrashed(ctx context.Context, taskID string) (*model.Task, error)
+	UpdateTask(ctx context.Context, taskID string, req dto.UpdateTaskRequest) (*model.Task, error)
+	PatchTask(ctx context.Context, taskID string, patch []jsonpatch.Operation, expectedVersion *int64) (*model.Task, error)
+	DeleteTask(ctx context.Context, taskID string, expectedVersion *int64, permanent bool) error
+	RestoreTask(ctx context.Context, taskID string) (*model.Task, error)
+	GetTaskHistory(ctx context.Context, taskID string) ([]history.Revision, error)
+	GetTagCounts(ctx context.Context) ([]model.TagCount, error)
+	BatchTasks(ctx context.Context, ops []dto.BatchTaskOperation, atomic bool) ([]model.BatchResult, error)
 }
 
 type taskService struct {
-	grpcClient client.TaskClient
+	grpcClient   client.TaskClient
+	capabilities config.DBServiceCapabilities
+	export       config.ExportConfig
+	publisher    events.Publisher
+	history      history.Store
+	access       taskAccess
 }
 
-func NewTaskService(taskClient client.TaskClient) TaskService {
+func NewTaskService(taskClient client.TaskClient, capabilities config.DBServiceCapabilities, exportConfig config.ExportConfig, publisher events.Publisher, historyStore history.Store) TaskService {
 	return &taskService{
-		grpcClient: taskClient,
+		grpcClient:   taskClient,
+		capabilities: capabilities,
+		export:       exportConfig,
+		publisher:    publisher,
+		history:      historyStore,
+		access:       taskAccess{grpcClient: taskClient},
 	}
 }
 
@@ -45,9 +66,17 @@ func (t *taskService) CreateTask(ctx context.Context, task *model.Task) (*model.
 		return nil, fmt.Errorf("task validation failed: %w", err)
 	}
 
+	if err := t.access.authorizeListTarget(ctx, &task.ListID); err != nil {
+		return nil, err
+	}
+
 	createReq := dto.CreateTaskRequest{
 		Title:       task.Title,
 		Description: task.Description,
+		DueAt:       task.DueAt,
+		Priority:    string(task.Priority),
+		Tags:        task.Tags,
+		ListID:      task.ListID,
 	}
 
 	protoReq := dto.CreateTaskRequestToProto(createReq)
@@ -72,14 +101,38 @@ func (t *taskService) CreateTask(ctx context.Context, task *model.Task) (*model.
 		slog.Duration("duration", duration),
 	)
 
+	t.publishEvent(ctx, dto.EventTaskCreated, createdTask)
+	t.recordRevision(ctx, history.OperationCreate, nil, createdTask)
+
 	return createdTask, nil
 }
 
-func (t *taskService) GetTasks(ctx context.Context, completed *bool) ([]*model.Task, int, error) {
+func (t *taskService) GetTasks(ctx context.Context, req dto.ListTasksRequest) (*model.TaskPage, error) {
 	start := time.Now()
 	operation := "GetTasks"
 
-	protoReq := dto.ListTasksRequestToProto()
+	if t.needsInMemoryListing(req) {
+		page, err := t.listTasksInMemory(ctx, req)
+		if err != nil {
+			logger.LogError(ctx, err, operation,
+				slog.Duration("duration", time.Since(start)),
+			)
+			return nil, err
+		}
+
+		slog.InfoContext(ctx, "Tasks retrieved successfully",
+			slog.String("operation", operation),
+			slog.Int("page_count", len(page.Tasks)),
+			slog.Int("total_count", page.TotalCount),
+			slog.Bool("has_next_page", page.NextCursor != ""),
+			slog.Bool("in_memory_filtering", true),
+			slog.Duration("duration", time.Since(start)),
+		)
+
+		return page, nil
+	}
+
+	protoReq := dto.ListTasksRequestToProto(req)
 
 	protoResp, err := t.grpcClient.ListTasks(ctx, protoReq)
 	duration := time.Since(start)
@@ -88,33 +141,25 @@ func (t *taskService) GetTasks(ctx context.Context, completed *bool) ([]*model.T
 		logger.LogError(ctx, err, operation,
 			slog.Duration("duration", duration),
 		)
-		return nil, 0, fmt.Errorf("failed to get tasks: %w", err)
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
 	}
 
-	allTasks := dto.ProtoToModelTasks(protoResp.Tasks)
-
-	var filteredTasks []*model.Task
-	if completed != nil {
-		filteredTasks = make([]*model.Task, 0)
-		for _, task := range allTasks {
-			if task.Completed == *completed {
-				filteredTasks = append(filteredTasks, task)
-			}
-		}
-	} else {
-		filteredTasks = allTasks
+	page := &model.TaskPage{
+		Tasks:      dto.ProtoToModelTasks(protoResp.Tasks),
+		NextCursor: protoResp.NextPageToken,
+		TotalCount: int(protoResp.TotalCount),
 	}
 
-	totalCount := len(filteredTasks)
-
 	slog.InfoContext(ctx, "Tasks retrieved successfully",
 		slog.String("operation", operation),
-		slog.Int("total_count", len(allTasks)),
-		slog.Int("filtered_count", totalCount),
+		slog.Int("page_count", len(page.Tasks)),
+		slog.Int("total_count", page.TotalCount),
+		slog.Bool("has_next_page", page.NextCursor != ""),
+		slog.Bool("in_memory_filtering", false),
 		slog.Duration("duration", duration),
 	)
 
-	return filteredTasks, totalCount, nil
+	return page, nil
 }
 
 func (t *taskService) GetTask(ctx context.Context, taskID string) (*model.Task, error) {
@@ -142,6 +187,14 @@ func (t *taskService) GetTask(ctx context.Context, taskID string) (*model.Task,
 
 	task := dto.ProtoToModelTask(protoResp.Task)
 
+	if err := t.access.checkTask(ctx, task, model.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	if task.DeletedAt != nil {
+		return nil, errTrashedTaskNotFound
+	}
+
 	slog.InfoContext(ctx, "Task retrieved successfully",
 		slog.String("operation", operation),
 		slog.String("task_id", task.ID),
@@ -151,7 +204,7 @@ func (t *taskService) GetTask(ctx context.Context, taskID string) (*model.Task,
 	return task, nil
 }
 
-func (t *taskService) UpdateTask(ctx context.Context, taskID string, title, description *string, completed *bool) (*model.Task, error) {
+func (t *taskService) UpdateTask(ctx context.Context, taskID string, updateReq dto.UpdateTaskRequest) (*model.Task, error) {
 	start := time.Now()
 	operation := "UpdateTask"
 
@@ -161,26 +214,29 @@ func (t *taskService) UpdateTask(ctx context.Context, taskID string, title, desc
 		return nil, err
 	}
 
-	if title == nil && description == nil && completed == nil {
+	if updateReq.IsEmpty() {
 		err := fmt.Errorf("at least one field must be provided for update")
 		logger.LogError(ctx, err, operation,
 			slog.String("task_id", taskID),
 		)
 		return nil, err
 	}
-	
-	updateReq := dto.UpdateTaskRequest{
-		Title:       title,
-		Description: description,
-		Completed:   completed,
-	}
 
-	if title != nil && *title == "" {
+	if updateReq.Title != nil && *updateReq.Title == "" {
 		err := fmt.Errorf("title cannot be empty")
 		logger.LogError(ctx, err, operation, slog.String("task_id", taskID))
 		return nil, err
 	}
 
+	before, err := t.access.authorizeMutation(ctx, taskID, model.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.access.authorizeListTarget(ctx, updateReq.ListID); err != nil {
+		return nil, err
+	}
+
 	protoReq := dto.UpdateTaskRequestToProto(taskID, updateReq)
 
 	protoResp, err := t.grpcClient.UpdateTask(ctx, protoReq)
@@ -202,10 +258,15 @@ func (t *taskService) UpdateTask(ctx context.Context, taskID string, title, desc
 		slog.Duration("duration", duration),
 	)
 
+	t.publishEvent(ctx, dto.EventTaskUpdated, updatedTask)
+	t.recordRevision(ctx, history.OperationUpdate, before, updatedTask)
+
 	return updatedTask, nil
 }
 
-func (t *taskService) DeleteTask(ctx context.Context, taskID string) error {
+// DeleteTask moves the task to the trash, or removes it for good when
+// permanent is set.
+func (t *taskService) DeleteTask(ctx context.Context, taskID string, expectedVersion *int64, permanent bool) error {
 	start := time.Now()
 	operation := "DeleteTask"
 
@@ -215,7 +276,19 @@ func (t *taskService) DeleteTask(ctx context.Context, taskID string) error {
 		return err
 	}
 
-	protoReq := dto.DeleteTaskRequestToProto(taskID)
+	// Only a permanent delete may target a task that is already in the
+	// trash.
+	authorize := t.access.authorizeMutation
+	if permanent {
+		authorize = t.access.authorize
+	}
+
+	snapshot, err := authorize(ctx, taskID, model.RoleOwner)
+	if err != nil {
+		return err
+	}
+
+	protoReq := dto.DeleteTaskRequestToProto(taskID, expectedVersion, permanent)
 
 	protoResp, err := t.grpcClient.DeleteTask(ctx, protoReq)
 	duration := time.Since(start)
@@ -239,8 +312,53 @@ func (t *taskService) DeleteTask(ctx context.Context, taskID string) error {
 	slog.InfoContext(ctx, "Task deleted successfully",
 		slog.String("operation", operation),
 		slog.String("task_id", taskID),
+		slog.Bool("permanent", permanent),
 		slog.Duration("duration", duration),
 	)
 
+	t.publishEvent(ctx, dto.EventTaskDeleted, snapshot)
+	t.recordDeletion(ctx, snapshot, permanent)
+
 	return nil
+}
+
+func (t *taskService) GetTagCounts(ctx context.Context) ([]model.TagCount, error) {
+	start := time.Now()
+	operation := "GetTagCounts"
+
+	protoResp, err := t.grpcClient.ListTags(ctx, dto.ListTagsRequestToProto())
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+		)
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	tags := dto.ProtoToTagCounts(protoResp.Tags)
+
+	slog.InfoContext(ctx, "Tags retrieved successfully",
+		slog.String("operation", operation),
+		slog.Int("count", len(tags)),
+		slog.Duration("duration", duration),
+	)
+
+	return tags, nil
+}
+
+func (t *taskService) publishEvent(ctx context.Context, eventType string, task *model.Task) {
+	publishTaskEvent(ctx, t.publisher, eventType, task)
+}
+
+func publishTaskEvent(ctx context.Context, publisher events.Publisher, eventType string, task *model.Task) {
+	event := events.NewTaskEvent(eventType, task, logger.RequestIDFromContext(ctx))
+
+	if err := publisher.Publish(ctx, event); err != nil {
+		logger.LogError(ctx, err, "PublishEvent",
+			slog.String("event_type", eventType),
+			slog.String("event_id", event.ID),
+			slog.String("task_id", event.TaskID),
+		)
+	}
 }
\ No newline at end of file
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/history"
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+	"github.com/Raisondetr3/checklist-api-service/pkg/logger"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errBatchAborted      = status.Error(codes.Aborted, "not applied because another operation in the atomic batch failed")
+	errBatchTaskNotFound = status.Error(codes.NotFound, "task not found")
+)
+
+// BatchTasks applies the operations with a single BatchMutate call. The
+// affected tasks are loaded with one BatchGetTasks call and access checks run
+// first; in atomic mode any failure leaves every operation unapplied.
+func (t *taskService) BatchTasks(ctx context.Context, ops []dto.BatchTaskOperation, atomic bool) ([]model.BatchResult, error) {
+	start := time.Now()
+	operation := "BatchTasks"
+
+	snapshots, err := t.batchSnapshots(ctx, ops)
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", time.Since(start)),
+			slog.Int("operations", len(ops)),
+		)
+		return nil, fmt.Errorf("failed to load batch tasks: %w", err)
+	}
+
+	results := make([]model.BatchResult, len(ops))
+	lists := t.access.cachedLists()
+
+	var pending []int
+	for i, op := range ops {
+		var err error
+		switch op.Op {
+		case dto.BatchOpCreate:
+			err = authorizeListTargetWith(ctx, lists, &op.Create.ListID)
+		case dto.BatchOpUpdate:
+			err = t.checkBatchTask(ctx, snapshots[i], model.RoleEditor, false, lists)
+			if err == nil {
+				err = authorizeListTargetWith(ctx, lists, op.Update.ListID)
+			}
+		case dto.BatchOpDelete:
+			err = t.checkBatchTask(ctx, snapshots[i], model.RoleOwner, op.Permanent, lists)
+		}
+
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	if atomic && len(pending) < len(ops) {
+		for _, i := range pending {
+			results[i].Err = errBatchAborted
+		}
+		return results, nil
+	}
+	if len(pending) == 0 {
+		return results, nil
+	}
+
+	pendingOps := make([]dto.BatchTaskOperation, len(pending))
+	for j, i := range pending {
+		pendingOps[j] = ops[i]
+	}
+
+	protoResp, err := t.grpcClient.BatchMutate(ctx, dto.BatchTaskOperationsToProto(pendingOps, atomic))
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.Int("operations", len(pendingOps)),
+			slog.Bool("atomic", atomic),
+		)
+		return nil, fmt.Errorf("failed to apply task batch: %w", err)
+	}
+
+	if len(protoResp.Results) != len(pendingOps) {
+		err := fmt.Errorf("batch returned %d results for %d operations", len(protoResp.Results), len(pendingOps))
+		logger.LogError(ctx, err, operation, slog.Bool("atomic", atomic))
+		return nil, err
+	}
+
+	failed := len(ops) - len(pending)
+	for j, i := range pending {
+		protoResult := protoResp.Results[j]
+		if protoResult.Code != int32(codes.OK) {
+			results[i].Err = status.Error(codes.Code(protoResult.Code), protoResult.Message)
+			failed++
+			continue
+		}
+
+		if protoResult.Task != nil {
+			results[i].Task = dto.ProtoToModelTask(protoResult.Task)
+		}
+
+		switch {
+		case ops[i].Op == dto.BatchOpCreate && results[i].Task != nil:
+			t.publishEvent(ctx, dto.EventTaskCreated, results[i].Task)
+			t.recordRevision(ctx, history.OperationCreate, nil, results[i].Task)
+		case ops[i].Op == dto.BatchOpUpdate && results[i].Task != nil:
+			t.publishEvent(ctx, dto.EventTaskUpdated, results[i].Task)
+			t.recordRevision(ctx, history.OperationUpdate, snapshots[i], results[i].Task)
+		case ops[i].Op == dto.BatchOpDelete:
+			t.publishEvent(ctx, dto.EventTaskDeleted, snapshots[i])
+			t.recordDeletion(ctx, snapshots[i], ops[i].Permanent)
+		}
+	}
+
+	slog.InfoContext(ctx, "Task batch applied",
+		slog.String("operation", operation),
+		slog.Int("operations", len(ops)),
+		slog.Int("failed", failed),
+		slog.Bool("atomic", atomic),
+		slog.Duration("duration", duration),
+	)
+
+	return results, nil
+}
+
+// batchSnapshots loads every task the batch updates or deletes with one
+// BatchGetTasks call. The snapshots serve the access checks and are the
+// "before" state recorded in history. Tasks that do not exist are left nil.
+func (t *taskService) batchSnapshots(ctx context.Context, ops []dto.BatchTaskOperation) ([]*model.Task, error) {
+	snapshots := make([]*model.Task, len(ops))
+
+	var ids []string
+	seen := make(map[string]bool)
+	for _, op := range ops {
+		if op.Op != dto.BatchOpCreate && !seen[op.TaskID] {
+			seen[op.TaskID] = true
+			ids = append(ids, op.TaskID)
+		}
+	}
+	if len(ids) == 0 {
+		return snapshots, nil
+	}
+
+	protoResp, err := t.grpcClient.BatchGetTasks(ctx, dto.BatchGetTasksRequestToProto(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make(map[string]*model.Task, len(protoResp.Tasks))
+	for _, task := range dto.ProtoToModelTasks(protoResp.Tasks) {
+		tasks[task.ID] = task
+	}
+	for i, op := range ops {
+		if op.Op != dto.BatchOpCreate {
+			snapshots[i] = tasks[op.TaskID]
+		}
+	}
+
+	return snapshots, nil
+}
+
+// checkBatchTask applies the same checks as authorizeMutation to a
+// preloaded task; trashed tasks are only allowed when allowTrashed is set.
+func (t *taskService) checkBatchTask(ctx context.Context, task *model.Task, required model.Role, allowTrashed bool, lists listLookup) error {
+	if task == nil {
+		return errBatchTaskNotFound
+	}
+	if err := t.access.checkTaskWith(ctx, task, required, lists); err != nil {
+		return err
+	}
+	if allowTrashed {
+		return nil
+	}
+	return checkNotTrashed(task)
+}
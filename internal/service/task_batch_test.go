@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/config"
+	"github.com/Raisondetr3/checklist-api-service/internal/events"
+	"github.com/Raisondetr3/checklist-api-service/internal/history"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+	pb "github.com/Raisondetr3/checklist-api-service/pkg/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newBatchTestService(fake *fakeTaskClient) (*taskService, *history.MemoryStore) {
+	store := history.NewMemoryStore(100, time.Hour)
+	svc := NewTaskService(fake, config.DBServiceCapabilities{}, config.ExportConfig{MaxRows: 100}, events.NewNopPublisher(), store)
+	return svc.(*taskService), store
+}
+
+func updateOp(taskID, title string) dto.BatchTaskOperation {
+	return dto.BatchTaskOperation{Op: dto.BatchOpUpdate, TaskID: taskID, Update: &dto.UpdateTaskRequest{Title: &title}}
+}
+
+func deleteOp(taskID string) dto.BatchTaskOperation {
+	return dto.BatchTaskOperation{Op: dto.BatchOpDelete, TaskID: taskID}
+}
+
+func createOp(title, listID string) dto.BatchTaskOperation {
+	return dto.BatchTaskOperation{Op: dto.BatchOpCreate, Create: &dto.CreateTaskRequest{Title: title, ListID: listID}}
+}
+
+func TestBatchTasksResults(t *testing.T) {
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		ops         []dto.BatchTaskOperation
+		atomic      bool
+		wantCodes   []codes.Code
+		wantMutated int
+	}{
+		{
+			name:        "all allowed",
+			ctx:         withSubject("alice"),
+			ops:         []dto.BatchTaskOperation{updateOp("own", "a"), updateOp("in-list", "b"), createOp("c", "groceries")},
+			wantCodes:   []codes.Code{codes.OK, codes.OK, codes.OK},
+			wantMutated: 3,
+		},
+		{
+			name:        "denied and missing tasks fail alone",
+			ctx:         withSubject("alice"),
+			ops:         []dto.BatchTaskOperation{updateOp("own", "a"), updateOp("shared", "b"), deleteOp("missing"), deleteOp("in-list")},
+			wantCodes:   []codes.Code{codes.OK, codes.PermissionDenied, codes.NotFound, codes.PermissionDenied},
+			wantMutated: 1,
+		},
+		{
+			name:        "atomic batch aborts on any failure",
+			ctx:         withSubject("alice"),
+			ops:         []dto.BatchTaskOperation{updateOp("own", "a"), updateOp("ownerless", "b")},
+			atomic:      true,
+			wantCodes:   []codes.Code{codes.Aborted, codes.PermissionDenied},
+			wantMutated: 0,
+		},
+		{
+			name:        "create needs editor access to the list",
+			ctx:         withSubject("alice"),
+			ops:         []dto.BatchTaskOperation{createOp("a", "team"), createOp("b", "")},
+			wantCodes:   []codes.Code{codes.PermissionDenied, codes.OK},
+			wantMutated: 1,
+		},
+		{
+			name:        "missing task without a principal",
+			ctx:         context.Background(),
+			ops:         []dto.BatchTaskOperation{updateOp("missing", "a"), updateOp("ownerless", "b")},
+			wantCodes:   []codes.Code{codes.NotFound, codes.OK},
+			wantMutated: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newAccessFixture()
+			svc, _ := newBatchTestService(fake)
+
+			results, err := svc.BatchTasks(tt.ctx, tt.ops, tt.atomic)
+			if err != nil {
+				t.Fatalf("BatchTasks() error = %v", err)
+			}
+
+			for i, result := range results {
+				if got := status.Code(result.Err); got != tt.wantCodes[i] {
+					t.Errorf("operation %d code = %v, want %v (err: %v)", i, got, tt.wantCodes[i], result.Err)
+				}
+			}
+			if len(fake.mutated) != tt.wantMutated {
+				t.Errorf("BatchMutate received %d operations, want %d", len(fake.mutated), tt.wantMutated)
+			}
+		})
+	}
+}
+
+func TestBatchTasksLoadsSnapshotsOnce(t *testing.T) {
+	fake := newAccessFixture()
+	fake.tasks = make(map[string]*pb.Task)
+	var ops []dto.BatchTaskOperation
+	for i := range 200 {
+		id := fmt.Sprintf("task-%d", i)
+		fake.tasks[id] = &pb.Task{Id: id, Title: "old", OwnerId: "bob", ListId: "groceries"}
+		ops = append(ops, updateOp(id, "new"))
+	}
+	svc, store := newBatchTestService(fake)
+
+	results, err := svc.BatchTasks(withSubject("alice"), ops, true)
+	if err != nil {
+		t.Fatalf("BatchTasks() error = %v", err)
+	}
+	for i, result := range results {
+		if result.Err != nil {
+			t.Fatalf("operation %d failed: %v", i, result.Err)
+		}
+	}
+
+	if fake.batchGetTasksCalls != 1 {
+		t.Errorf("BatchGetTasks called %d times, want 1", fake.batchGetTasksCalls)
+	}
+	if fake.getTaskCalls != 0 {
+		t.Errorf("GetTask called %d times, want 0", fake.getTaskCalls)
+	}
+	if fake.getListCalls != 1 {
+		t.Errorf("GetList called %d times, want 1", fake.getListCalls)
+	}
+
+	revisions, _ := store.List(context.Background(), "task-0")
+	if len(revisions) != 1 || revisions[0].Operation != history.OperationUpdate {
+		t.Fatalf("revisions = %+v, want one update", revisions)
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/internal/validator"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+	"github.com/Raisondetr3/checklist-api-service/pkg/logger"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExportTasks walks every page matching req, starting at req.Cursor, and
+// hands each page to write as soon as it is loaded so callers can stream
+// the result instead of holding it in memory.
+//
+// An export may hold at most export.MaxRows tasks. When the first page
+// reports a larger total the export is refused before anything is
+// written; otherwise it is cut off with an error once the limit is hit.
+//
+// When db-service cannot filter the listing, the collection is loaded once
+// and every page is cut from that snapshot.
+func (t *taskService) ExportTasks(ctx context.Context, req dto.ListTasksRequest, write func([]*model.Task) error) error {
+	start := time.Now()
+	operation := "ExportTasks"
+
+	maxRows := t.export.MaxRows
+	req.Limit = min(validator.MaxPageSize, maxRows)
+
+	getPage := t.GetTasks
+	if t.needsInMemoryListing(req) {
+		pager, err := t.inMemoryPager(ctx, req)
+		if err != nil {
+			logger.LogError(ctx, err, operation,
+				slog.Bool("in_memory_filtering", true),
+				slog.Duration("duration", time.Since(start)),
+			)
+			return err
+		}
+		getPage = pager
+	}
+
+	pages, count := 0, 0
+	for {
+		page, err := getPage(ctx, req)
+		if err != nil {
+			logger.LogError(ctx, err, operation,
+				slog.Int("pages", pages),
+				slog.Int("count", count),
+				slog.Duration("duration", time.Since(start)),
+			)
+			return err
+		}
+
+		if pages == 0 && req.Cursor == "" && page.TotalCount > maxRows {
+			return status.Errorf(codes.InvalidArgument,
+				"export matches %d tasks, more than the limit of %d; narrow the filters", page.TotalCount, maxRows)
+		}
+
+		tasks := page.Tasks
+		if count+len(tasks) > maxRows {
+			tasks = tasks[:maxRows-count]
+		}
+
+		if err := write(tasks); err != nil {
+			return fmt.Errorf("failed to write exported tasks: %w", err)
+		}
+
+		pages++
+		count += len(tasks)
+
+		if page.NextCursor == "" && len(tasks) == len(page.Tasks) {
+			break
+		}
+		if count >= maxRows {
+			err := fmt.Errorf("export stopped at the limit of %d tasks", maxRows)
+			logger.LogError(ctx, err, operation,
+				slog.Int("pages", pages),
+				slog.Int("count", count),
+				slog.Duration("duration", time.Since(start)),
+			)
+			return err
+		}
+		req.Cursor = page.NextCursor
+	}
+
+	slog.InfoContext(ctx, "Tasks exported successfully",
+		slog.String("operation", operation),
+		slog.Int("pages", pages),
+		slog.Int("count", count),
+		slog.Duration("duration", time.Since(start)),
+	)
+
+	return nil
+}
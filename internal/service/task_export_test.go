@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/client"
+	"github.com/Raisondetr3/checklist-api-service/internal/config"
+	"github.com/Raisondetr3/checklist-api-service/internal/events"
+	"github.com/Raisondetr3/checklist-api-service/internal/history"
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+	pb "github.com/Raisondetr3/checklist-api-service/pkg/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pagingTaskClient pages through tasks using the offset as the page token.
+type pagingTaskClient struct {
+	client.TaskClient
+
+	tasks       []*pb.Task
+	reportTotal bool
+	calls       int
+}
+
+func (c *pagingTaskClient) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	c.calls++
+	offset, _ := strconv.Atoi(req.PageToken)
+	end := min(offset+int(req.PageSize), len(c.tasks))
+
+	resp := &pb.ListTasksResponse{Tasks: c.tasks[offset:end]}
+	if end < len(c.tasks) {
+		resp.NextPageToken = strconv.Itoa(end)
+	}
+	if c.reportTotal {
+		resp.TotalCount = int32(len(c.tasks))
+	}
+	return resp, nil
+}
+
+func TestExportTasksRowLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		tasks       int
+		maxRows     int
+		reportTotal bool
+		inMemory    bool
+		wantRows    int
+		wantCode    codes.Code
+	}{
+		{name: "under the limit", tasks: 5, maxRows: 10, reportTotal: true, wantRows: 5},
+		{name: "exactly the limit", tasks: 10, maxRows: 10, reportTotal: true, wantRows: 10},
+		{name: "over the limit is refused up front", tasks: 11, maxRows: 10, reportTotal: true, wantCode: codes.InvalidArgument},
+		{name: "over the limit without a total is cut off", tasks: 11, maxRows: 10, wantRows: 10, wantCode: codes.Unknown},
+		{name: "in-memory fallback reports the full total", tasks: 11, maxRows: 10, inMemory: true, wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &pagingTaskClient{reportTotal: tt.reportTotal}
+			for i := range tt.tasks {
+				fake.tasks = append(fake.tasks, &pb.Task{Id: fmt.Sprintf("task-%d", i)})
+			}
+			capabilities := config.DBServiceCapabilities{ListFilters: !tt.inMemory, Search: !tt.inMemory}
+			svc := NewTaskService(fake, capabilities, config.ExportConfig{MaxRows: tt.maxRows}, events.NewNopPublisher(), history.NewMemoryStore(10, time.Hour))
+
+			rows := 0
+			err := svc.ExportTasks(context.Background(), dto.ListTasksRequest{}, func(tasks []*model.Task) error {
+				rows += len(tasks)
+				return nil
+			})
+
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("code = %v, want %v (err: %v)", got, tt.wantCode, err)
+			}
+			if rows != tt.wantRows {
+				t.Errorf("wrote %d rows, want %d", rows, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestExportTasksInMemoryScansOnce(t *testing.T) {
+	// 250 tasks take three db-service pages to scan and three export pages
+	// to write; rescanning per export page would cost nine calls.
+	fake := &pagingTaskClient{}
+	for i := range 250 {
+		fake.tasks = append(fake.tasks, &pb.Task{Id: fmt.Sprintf("task-%03d", i)})
+	}
+	svc := NewTaskService(fake, config.DBServiceCapabilities{}, config.ExportConfig{MaxRows: 1000}, events.NewNopPublisher(), history.NewMemoryStore(10, time.Hour))
+
+	var ids []string
+	err := svc.ExportTasks(context.Background(), dto.ListTasksRequest{Sort: []dto.SortField{{Field: "id", Descending: true}}}, func(tasks []*model.Task) error {
+		for _, task := range tasks {
+			ids = append(ids, task.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExportTasks: %v", err)
+	}
+
+	if fake.calls != 3 {
+		t.Errorf("db-service was called %d times, want 3 for a single scan", fake.calls)
+	}
+	if len(ids) != 250 || ids[0] != "task-249" || ids[len(ids)-1] != "task-000" {
+		t.Errorf("exported %d tasks, want all 250 in descending order", len(ids))
+	}
+}
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/internal/validator"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultMaxInMemoryTasks is used when no cap is configured in
+// config.DBServiceCapabilities.MaxInMemoryTasks.
+const defaultMaxInMemoryTasks = 1000
+
+// inMemoryCursorPrefix marks cursors that hold an offset into the filtered
+// collection rather than a db-service page token.
+const inMemoryCursorPrefix = "m:"
+
+func (t *taskService) needsInMemoryListing(req dto.ListTasksRequest) bool {
+	return !t.capabilities.ListFilters || (!t.capabilities.Search && req.Query != "")
+}
+
+func (t *taskService) maxInMemoryTasks() int {
+	if t.capabilities.MaxInMemoryTasks > 0 {
+		return t.capabilities.MaxInMemoryTasks
+	}
+	return defaultMaxInMemoryTasks
+}
+
+// listTasksInMemory answers a list request from a snapshot of the whole
+// collection and cuts the requested page from it. The total and cursors
+// therefore describe the filtered collection, not a single db-service page.
+func (t *taskService) listTasksInMemory(ctx context.Context, req dto.ListTasksRequest) (*model.TaskPage, error) {
+	offset, err := parseInMemoryCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := t.loadTasksInMemory(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return pageInMemory(tasks, offset, req.Limit), nil
+}
+
+// inMemoryPager loads the snapshot for req once and returns a GetTasks
+// replacement that pages through it, so walking every page does not rescan
+// db-service for each one.
+func (t *taskService) inMemoryPager(ctx context.Context, req dto.ListTasksRequest) (func(context.Context, dto.ListTasksRequest) (*model.TaskPage, error), error) {
+	if _, err := parseInMemoryCursor(req.Cursor); err != nil {
+		return nil, err
+	}
+
+	tasks, err := t.loadTasksInMemory(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(_ context.Context, req dto.ListTasksRequest) (*model.TaskPage, error) {
+		offset, err := parseInMemoryCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		return pageInMemory(tasks, offset, req.Limit), nil
+	}, nil
+}
+
+// loadTasksInMemory loads the whole collection from db-service and applies
+// the filters, search and sort order the backend cannot. Collections larger
+// than the configured cap are refused rather than answered from a partial
+// scan.
+func (t *taskService) loadTasksInMemory(ctx context.Context, req dto.ListTasksRequest) ([]*model.Task, error) {
+	maxTasks := t.maxInMemoryTasks()
+
+	scanReq := req
+	scanReq.Limit = validator.MaxPageSize
+	scanReq.Cursor = ""
+
+	var tasks []*model.Task
+	for {
+		protoResp, err := t.grpcClient.ListTasks(ctx, dto.ListTasksRequestToProto(scanReq))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tasks: %w", err)
+		}
+
+		tasks = append(tasks, dto.ProtoToModelTasks(protoResp.Tasks)...)
+		if len(tasks) > maxTasks {
+			slog.WarnContext(ctx, "Collection is too large to list in memory; raise DB_SERVICE_MAX_IN_MEMORY_TASKS or enable db-service filtering",
+				slog.Int("max_in_memory_tasks", maxTasks),
+			)
+			return nil, status.Errorf(codes.Unimplemented,
+				"db-service cannot filter, search or sort tasks and the collection has more than %d tasks", maxTasks)
+		}
+
+		if protoResp.NextPageToken == "" {
+			break
+		}
+		scanReq.Cursor = protoResp.NextPageToken
+	}
+
+	if !t.capabilities.ListFilters {
+		tasks = filterTasks(tasks, req)
+		sortTasks(tasks, req.Sort)
+	}
+	if !t.capabilities.Search && req.Query != "" {
+		tasks = searchTasks(tasks, model.ParseSearchQuery(req.Query))
+	}
+
+	return tasks, nil
+}
+
+func pageInMemory(tasks []*model.Task, offset, limit int) *model.TaskPage {
+	if limit <= 0 {
+		limit = validator.DefaultPageSize
+	}
+
+	page := &model.TaskPage{TotalCount: len(tasks)}
+	if offset >= len(tasks) {
+		page.Tasks = []*model.Task{}
+		return page
+	}
+
+	end := min(offset+limit, len(tasks))
+	page.Tasks = tasks[offset:end]
+	if end < len(tasks) {
+		page.NextCursor = inMemoryCursorPrefix + strconv.Itoa(end)
+	}
+
+	return page
+}
+
+func parseInMemoryCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	offset, err := strconv.Atoi(strings.TrimPrefix(cursor, inMemoryCursorPrefix))
+	if !strings.HasPrefix(cursor, inMemoryCursorPrefix) || err != nil || offset < 0 {
+		return 0, status.Error(codes.InvalidArgument, validator.ErrInvalidCursorParameter.Error())
+	}
+	return offset, nil
+}
+
+// filterTasks applies list filters in memory for db-service versions that
+// ignore the filter fields of ListTasksRequest.
+func filterTasks(tasks []*model.Task, req dto.ListTasksRequest) []*model.Task {
+	now := time.Now()
+
+	filtered := make([]*model.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if matchesListFilters(task, req, now) {
+			filtered = append(filtered, task)
+		}
+	}
+
+	return filtered
+}
+
+func matchesListFilters(task *model.Task, req dto.ListTasksRequest, now time.Time) bool {
+	if (task.DeletedAt != nil) != req.Deleted {
+		return false
+	}
+	if req.Completed != nil && task.Completed != *req.Completed {
+		return false
+	}
+	if req.ListID != "" && task.ListID != req.ListID {
+		return false
+	}
+	if req.CreatedAfter != nil && !task.CreatedAt.After(*req.CreatedAfter) {
+		return false
+	}
+	if req.CreatedBefore != nil && !task.CreatedAt.Before(*req.CreatedBefore) {
+		return false
+	}
+	if req.UpdatedAfter != nil && !task.UpdatedAt.After(*req.UpdatedAfter) {
+		return false
+	}
+	if req.UpdatedBefore != nil && !task.UpdatedAt.Before(*req.UpdatedBefore) {
+		return false
+	}
+	if req.Overdue != nil && task.IsOverdue(now) != *req.Overdue {
+		return false
+	}
+	if req.DueBefore != nil && (task.DueAt == nil || !task.DueAt.Before(*req.DueBefore)) {
+		return false
+	}
+	if len(req.Priorities) > 0 && !slices.Contains(req.Priorities, task.Priority) {
+		return false
+	}
+	if len(req.Tags) > 0 && !matchesTags(task, req.Tags, req.MatchAllTags) {
+		return false
+	}
+
+	return true
+}
+
+func matchesTags(task *model.Task, tags []string, matchAll bool) bool {
+	for _, tag := range tags {
+		hasTag := task.HasTag(tag)
+		if matchAll && !hasTag {
+			return false
+		}
+		if !matchAll && hasTag {
+			return true
+		}
+	}
+
+	return matchAll
+}
+
+func searchTasks(tasks []*model.Task, query model.SearchQuery) []*model.Task {
+	if query.IsEmpty() {
+		return tasks
+	}
+
+	matched := make([]*model.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if query.Matches(task) {
+			matched = append(matched, task)
+		}
+	}
+
+	return matched
+}
+
+func sortTasks(tasks []*model.Task, fields []dto.SortField) {
+	if len(fields) == 0 {
+		return
+	}
+
+	slices.SortStableFunc(tasks, func(a, b *model.Task) int {
+		for _, field := range fields {
+			result := compareTasksBy(a, b, field.Field)
+			if field.Descending {
+				result = -result
+			}
+			if result != 0 {
+				return result
+			}
+		}
+		return 0
+	})
+}
+
+func compareTasksBy(a, b *model.Task, field string) int {
+	switch field {
+	case "id":
+		return strings.Compare(a.ID, b.ID)
+	case "title":
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	case "completed":
+		return compareBools(a.Completed, b.Completed)
+	case "created_at":
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case "updated_at":
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	case "due_at":
+		return compareOptionalTimes(a.DueAt, b.DueAt)
+	case "priority":
+		return cmp.Compare(a.Priority.Rank(), b.Priority.Rank())
+	default:
+		return 0
+	}
+}
+
+// compareOptionalTimes orders tasks without a due date after all others.
+func compareOptionalTimes(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	default:
+		return a.Compare(*b)
+	}
+}
+
+func compareBools(a, b bool) int {
+	return cmp.Compare(boolToInt(a), boolToInt(b))
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
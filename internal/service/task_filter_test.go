@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/client"
+	"github.com/Raisondetr3/checklist-api-service/internal/config"
+	"github.com/Raisondetr3/checklist-api-service/internal/events"
+	"github.com/Raisondetr3/checklist-api-service/internal/history"
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+	pb "github.com/Raisondetr3/checklist-api-service/pkg/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newInMemoryListingService(tasks []*pb.Task) TaskService {
+	fake := &pagingTaskClient{tasks: tasks, reportTotal: true}
+	return NewTaskService(fake, config.DBServiceCapabilities{}, config.ExportConfig{MaxRows: 100}, events.NewNopPublisher(), history.NewMemoryStore(10, time.Hour))
+}
+
+func TestGetTasksInMemoryPaging(t *testing.T) {
+	// 250 tasks span three db-service pages; every third one is completed.
+	var tasks []*pb.Task
+	for i := range 250 {
+		tasks = append(tasks, &pb.Task{Id: fmt.Sprintf("task-%03d", i), Completed: i%3 == 0})
+	}
+	svc := newInMemoryListingService(tasks)
+
+	completed := true
+	req := dto.ListTasksRequest{
+		Limit:     40,
+		Completed: &completed,
+		Sort:      []dto.SortField{{Field: "id", Descending: true}},
+	}
+
+	var seen []*model.Task
+	pages := 0
+	for {
+		page, err := svc.GetTasks(context.Background(), req)
+		if err != nil {
+			t.Fatalf("GetTasks: %v", err)
+		}
+		if page.TotalCount != 84 {
+			t.Fatalf("total = %d, want 84 matches across all db-service pages", page.TotalCount)
+		}
+		if page.NextCursor != "" && len(page.Tasks) != req.Limit {
+			t.Fatalf("page %d has %d tasks but more follow, want full pages", pages, len(page.Tasks))
+		}
+
+		seen = append(seen, page.Tasks...)
+		pages++
+		if page.NextCursor == "" {
+			break
+		}
+		req.Cursor = page.NextCursor
+	}
+
+	if pages != 3 || len(seen) != 84 {
+		t.Fatalf("got %d tasks in %d pages, want 84 in 3", len(seen), pages)
+	}
+	if seen[0].ID != "task-249" || seen[len(seen)-1].ID != "task-000" {
+		t.Errorf("order runs %s..%s, want a global descending sort", seen[0].ID, seen[len(seen)-1].ID)
+	}
+}
+
+func TestGetTasksInMemoryLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		tasks    int
+		maxTasks int
+		cursor   string
+		wantCode codes.Code
+	}{
+		{name: "at the default cap", tasks: defaultMaxInMemoryTasks},
+		{name: "over the default cap", tasks: defaultMaxInMemoryTasks + 1, wantCode: codes.Unimplemented},
+		{name: "at a configured cap", tasks: 20, maxTasks: 20},
+		{name: "over a configured cap", tasks: 21, maxTasks: 20, wantCode: codes.Unimplemented},
+		{name: "db-service cursor", tasks: 5, cursor: "100", wantCode: codes.InvalidArgument},
+		{name: "negative offset", tasks: 5, cursor: inMemoryCursorPrefix + "-1", wantCode: codes.InvalidArgument},
+		{name: "offset past the end", tasks: 5, cursor: inMemoryCursorPrefix + "10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &pagingTaskClient{reportTotal: true}
+			for i := range tt.tasks {
+				fake.tasks = append(fake.tasks, &pb.Task{Id: fmt.Sprintf("task-%d", i)})
+			}
+			capabilities := config.DBServiceCapabilities{MaxInMemoryTasks: tt.maxTasks}
+			svc := NewTaskService(fake, capabilities, config.ExportConfig{MaxRows: 100}, events.NewNopPublisher(), history.NewMemoryStore(10, time.Hour))
+
+			_, err := svc.GetTasks(context.Background(), dto.ListTasksRequest{Limit: 10, Cursor: tt.cursor})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("code = %v, want %v (err: %v)", got, tt.wantCode, err)
+			}
+		})
+	}
+}
+
+// recordingTaskClient answers ListTasks with a fixed response and keeps the
+// request it was sent.
+type recordingTaskClient struct {
+	client.TaskClient
+
+	resp *pb.ListTasksResponse
+	req  *pb.ListTasksRequest
+}
+
+func (c *recordingTaskClient) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	c.req = req
+	return c.resp, nil
+}
+
+func TestGetTasksDelegatesFilters(t *testing.T) {
+	fake := &recordingTaskClient{resp: &pb.ListTasksResponse{
+		// db-service has already filtered; the service must not filter again.
+		Tasks:         []*pb.Task{{Id: "task-1", Completed: false}},
+		NextPageToken: "next",
+		TotalCount:    42,
+	}}
+	capabilities := config.DBServiceCapabilities{ListFilters: true, Search: true}
+	svc := NewTaskService(fake, capabilities, config.ExportConfig{MaxRows: 100}, events.NewNopPublisher(), history.NewMemoryStore(10, time.Hour))
+
+	completed := true
+	page, err := svc.GetTasks(context.Background(), dto.ListTasksRequest{Limit: 20, Completed: &completed, ListID: "groceries"})
+	if err != nil {
+		t.Fatalf("GetTasks: %v", err)
+	}
+
+	if fake.req.Completed == nil || !*fake.req.Completed || fake.req.ListId != "groceries" || fake.req.PageSize != 20 {
+		t.Errorf("request = %+v, want completed=true, list groceries and page size 20", fake.req)
+	}
+	if page.TotalCount != 42 || page.NextCursor != "next" || len(page.Tasks) != 1 {
+		t.Errorf("page = %d tasks, total %d, next %q; want db-service's page unchanged", len(page.Tasks), page.TotalCount, page.NextCursor)
+	}
+}
+
+func TestMatchesListFilters(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+	later := now.Add(time.Hour)
+	yes, no := true, false
+
+	task := &model.Task{
+		ID:        "task-1",
+		Completed: true,
+		ListID:    "groceries",
+		CreatedAt: now,
+		UpdatedAt: now,
+		DueAt:     &earlier,
+		Priority:  model.PriorityHigh,
+		Tags:      []string{"home", "errand"},
+	}
+
+	tests := []struct {
+		name string
+		req  dto.ListTasksRequest
+		want bool
+	}{
+		{name: "no filters", want: true},
+		{name: "completed", req: dto.ListTasksRequest{Completed: &yes}, want: true},
+		{name: "not completed", req: dto.ListTasksRequest{Completed: &no}},
+		{name: "same list", req: dto.ListTasksRequest{ListID: "groceries"}, want: true},
+		{name: "other list", req: dto.ListTasksRequest{ListID: "work"}},
+		{name: "created after", req: dto.ListTasksRequest{CreatedAfter: &earlier}, want: true},
+		{name: "created after is exclusive", req: dto.ListTasksRequest{CreatedAfter: &now}},
+		{name: "created before", req: dto.ListTasksRequest{CreatedBefore: &later}, want: true},
+		{name: "updated before is exclusive", req: dto.ListTasksRequest{UpdatedBefore: &now}},
+		{name: "trash only", req: dto.ListTasksRequest{Deleted: true}},
+		{name: "due before", req: dto.ListTasksRequest{DueBefore: &now}, want: true},
+		{name: "priority", req: dto.ListTasksRequest{Priorities: []model.Priority{model.PriorityLow, model.PriorityHigh}}, want: true},
+		{name: "other priority", req: dto.ListTasksRequest{Priorities: []model.Priority{model.PriorityLow}}},
+		{name: "any tag", req: dto.ListTasksRequest{Tags: []string{"work", "home"}}, want: true},
+		{name: "all tags", req: dto.ListTasksRequest{Tags: []string{"errand", "home"}, MatchAllTags: true}, want: true},
+		{name: "all tags missing one", req: dto.ListTasksRequest{Tags: []string{"work", "home"}, MatchAllTags: true}},
+		{name: "filters combine", req: dto.ListTasksRequest{Completed: &yes, ListID: "work"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesListFilters(task, tt.req, now); got != tt.want {
+				t.Errorf("matchesListFilters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
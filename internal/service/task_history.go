@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/auth"
+	"github.com/Raisondetr3/checklist-api-service/internal/history"
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/pkg/logger"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	anonymousActor = "anonymous"
+	// systemActor is recorded for changes made by background jobs.
+	systemActor = "system"
+)
+
+// GetTaskHistory returns the task's revisions. Once a task is purged there
+// is nothing left to check access against, so the history kept for it is
+// only served to admins.
+func (t *taskService) GetTaskHistory(ctx context.Context, taskID string) ([]history.Revision, error) {
+	start := time.Now()
+	operation := "GetTaskHistory"
+
+	_, authErr := t.access.authorize(ctx, taskID, model.RoleViewer)
+	if authErr != nil && (status.Code(authErr) != codes.NotFound || !isAdmin(ctx)) {
+		return nil, authErr
+	}
+
+	revisions, err := t.history.List(ctx, taskID)
+	duration := time.Since(start)
+
+	if err == nil && authErr != nil && len(revisions) == 0 {
+		return nil, authErr
+	}
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.String("task_id", taskID),
+		)
+		return nil, fmt.Errorf("failed to load task history: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Task history retrieved successfully",
+		slog.String("operation", operation),
+		slog.String("task_id", taskID),
+		slog.Int("count", len(revisions)),
+		slog.Duration("duration", duration),
+	)
+
+	return revisions, nil
+}
+
+// recordRevision stores the change from before to after. Like events,
+// history is best effort and never fails the operation that produced it.
+func (t *taskService) recordRevision(ctx context.Context, operation string, before, after *model.Task) {
+	appendRevision(ctx, t.history, revisionActor(ctx), operation, before, after)
+}
+
+// isAdmin reports whether the caller may act on any task, which is also
+// the case when authentication is disabled.
+func isAdmin(ctx context.Context) bool {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	return !ok || principal.IsAdmin()
+}
+
+func revisionActor(ctx context.Context) string {
+	if actor := auth.SubjectFromContext(ctx); actor != "" {
+		return actor
+	}
+	return anonymousActor
+}
+
+func appendRevision(ctx context.Context, store history.Store, actor, operation string, before, after *model.Task) {
+	current := after
+	if current == nil {
+		current = before
+	}
+	if current == nil {
+		return
+	}
+
+	revision := history.Revision{
+		ID:        uuid.New().String(),
+		TaskID:    current.ID,
+		Version:   current.Version,
+		Actor:     actor,
+		Operation: operation,
+		Timestamp: time.Now().UTC(),
+		Changes:   history.Diff(before, after),
+	}
+
+	if err := store.Append(ctx, revision); err != nil {
+		logger.LogError(ctx, err, "RecordRevision",
+			slog.String("task_id", revision.TaskID),
+			slog.String("history_operation", operation),
+		)
+	}
+}
+
+func (t *taskService) recordDeletion(ctx context.Context, snapshot *model.Task, permanent bool) {
+	if permanent {
+		t.recordRevision(ctx, history.OperationPurge, snapshot, nil)
+		return
+	}
+
+	deletedAt := time.Now().UTC()
+	trashed := *snapshot
+	trashed.DeletedAt = &deletedAt
+	t.recordRevision(ctx, history.OperationDelete, snapshot, &trashed)
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/history"
+	"github.com/Raisondetr3/checklist-api-service/internal/jsonpatch"
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/internal/validator"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+	"github.com/Raisondetr3/checklist-api-service/pkg/logger"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errMixedItemPatch rejects patches that change task fields and /items
+// together: the item RPCs cannot share the field update's transaction, so a
+// failure part way through would leave the patch half applied.
+var errMixedItemPatch = status.Error(codes.InvalidArgument,
+	"JSON Patch cannot change /items together with other task fields; send them as separate requests")
+
+// PatchTask applies an RFC 6902 patch to the task's JSON representation and
+// writes the fields it changed. The update is conditional on the version
+// the patch was applied to, so concurrent edits are never overwritten.
+// Changes under /items go through the checklist item RPCs instead; see
+// itemPlan. They have no version guard of their own, so the version is
+// checked again right before they run.
+func (t *taskService) PatchTask(ctx context.Context, taskID string, patch []jsonpatch.Operation, expectedVersion *int64) (*model.Task, error) {
+	start := time.Now()
+	operation := "PatchTask"
+
+	current, err := t.access.authorizeMutation(ctx, taskID, model.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	if expectedVersion != nil && *expectedVersion != current.Version {
+		return nil, status.Error(codes.FailedPrecondition, validator.ErrPreconditionFailed.Error())
+	}
+
+	updateReq, items, err := taskPatchToUpdate(current, patch)
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.String("task_id", taskID),
+			slog.Int("operations", len(patch)),
+		)
+		return nil, err
+	}
+
+	if updateReq.IsEmpty() && items.isEmpty() {
+		return current, nil
+	}
+
+	if !updateReq.IsEmpty() && !items.isEmpty() {
+		return nil, errMixedItemPatch
+	}
+
+	patchedTask := current
+	if !updateReq.IsEmpty() {
+		if err := t.access.authorizeListTarget(ctx, updateReq.ListID); err != nil {
+			return nil, err
+		}
+
+		version := current.Version
+		updateReq.ExpectedVersion = &version
+
+		updateResp, err := t.grpcClient.UpdateTask(ctx, dto.UpdateTaskRequestToProto(taskID, updateReq))
+		if err != nil {
+			logger.LogError(ctx, err, operation,
+				slog.Duration("duration", time.Since(start)),
+				slog.String("task_id", taskID),
+			)
+			return nil, fmt.Errorf("failed to update task: %w", err)
+		}
+
+		patchedTask = dto.ProtoToModelTask(updateResp.Task)
+	}
+
+	if !items.isEmpty() {
+		if err := t.checkVersion(ctx, taskID, current.Version); err != nil {
+			return nil, err
+		}
+
+		if err := t.applyItemPlan(ctx, taskID, items); err != nil {
+			logger.LogError(ctx, err, operation,
+				slog.Duration("duration", time.Since(start)),
+				slog.String("task_id", taskID),
+			)
+			return nil, err
+		}
+
+		protoResp, err := t.grpcClient.GetTask(ctx, dto.GetTaskRequestToProto(taskID))
+		if err != nil {
+			logger.LogError(ctx, err, operation,
+				slog.Duration("duration", time.Since(start)),
+				slog.String("task_id", taskID),
+			)
+			return nil, fmt.Errorf("failed to get task: %w", err)
+		}
+		patchedTask = dto.ProtoToModelTask(protoResp.Task)
+	}
+
+	duration := time.Since(start)
+
+	slog.InfoContext(ctx, "Task patched successfully",
+		slog.String("operation", operation),
+		slog.String("task_id", patchedTask.ID),
+		slog.Int("operations", len(patch)),
+		slog.Any("fields", updateReq.Paths()),
+		slog.Duration("duration", duration),
+	)
+
+	t.publishEvent(ctx, dto.EventTaskUpdated, patchedTask)
+	t.recordRevision(ctx, history.OperationUpdate, current, patchedTask)
+
+	return patchedTask, nil
+}
+
+// checkVersion fails with FailedPrecondition when the task no longer has the
+// given version.
+func (t *taskService) checkVersion(ctx context.Context, taskID string, version int64) error {
+	protoResp, err := t.grpcClient.GetTask(ctx, dto.GetTaskRequestToProto(taskID))
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if dto.ProtoToModelTask(protoResp.Task).Version != version {
+		return status.Error(codes.FailedPrecondition, validator.ErrPreconditionFailed.Error())
+	}
+
+	return nil
+}
+
+// taskPatchToUpdate applies the patch to the task's API representation and
+// turns the changed members into a merge patch, which gets the same
+// validation as other updates. A changed items array becomes an itemPlan.
+func taskPatchToUpdate(task *model.Task, patch []jsonpatch.Operation) (dto.UpdateTaskRequest, itemPlan, error) {
+	original, err := taskDocument(task)
+	if err != nil {
+		return dto.UpdateTaskRequest{}, itemPlan{}, err
+	}
+
+	doc, err := taskDocument(task)
+	if err != nil {
+		return dto.UpdateTaskRequest{}, itemPlan{}, err
+	}
+
+	patched, err := jsonpatch.Apply(doc, patch)
+	if errors.Is(err, jsonpatch.ErrTestFailed) {
+		return dto.UpdateTaskRequest{}, itemPlan{}, status.Errorf(codes.Aborted, "JSON Patch %v", err)
+	}
+	if err != nil {
+		return dto.UpdateTaskRequest{}, itemPlan{}, status.Errorf(codes.InvalidArgument, "Invalid JSON Patch: %v", err)
+	}
+
+	patchedFields, ok := patched.(map[string]any)
+	if !ok {
+		return dto.UpdateTaskRequest{}, itemPlan{}, status.Error(codes.InvalidArgument, "Invalid JSON Patch: the task must remain a JSON object")
+	}
+
+	changes := make(map[string]any)
+	for name, value := range patchedFields {
+		if before, ok := original[name]; !ok || !reflect.DeepEqual(before, value) {
+			changes[name] = value
+		}
+	}
+	for name := range original {
+		if _, ok := patchedFields[name]; !ok {
+			changes[name] = nil
+		}
+	}
+
+	var items itemPlan
+	if value, ok := changes["items"]; ok {
+		items, err = planItemChanges(task.Items, value)
+		if err != nil {
+			return dto.UpdateTaskRequest{}, itemPlan{}, err
+		}
+		delete(changes, "items")
+	}
+
+	if len(changes) == 0 {
+		return dto.UpdateTaskRequest{}, items, nil
+	}
+
+	mergePatch, err := json.Marshal(changes)
+	if err != nil {
+		return dto.UpdateTaskRequest{}, itemPlan{}, fmt.Errorf("failed to encode task changes: %w", err)
+	}
+
+	updateReq, err := validator.ValidateMergePatchTaskRequest(mergePatch)
+	if err != nil {
+		return dto.UpdateTaskRequest{}, itemPlan{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return updateReq, items, nil
+}
+
+func taskDocument(task *model.Task) (map[string]any, error) {
+	data, err := json.Marshal(dto.TaskModelToResponse(task))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode task: %w", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode task: %w", err)
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/internal/validator"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// itemPlan is the set of checklist item RPCs that turn a task's items into
+// the patched /items array. Items are matched by ID; entries without an ID
+// are new. The array order is the item order, so "position" members are
+// only read to reject edits that try to reorder through them.
+type itemPlan struct {
+	creates []dto.CreateChecklistItemRequest
+	updates []itemUpdate
+	deletes []string
+
+	// order lists the final item IDs, with "" for each new item in the
+	// order it is created. It is nil when no reorder is needed.
+	order []string
+}
+
+type itemUpdate struct {
+	itemID string
+	req    dto.UpdateChecklistItemRequest
+}
+
+func (p itemPlan) isEmpty() bool {
+	return len(p.creates) == 0 && len(p.updates) == 0 && len(p.deletes) == 0 && p.order == nil
+}
+
+func planItemChanges(current []model.ChecklistItem, value any) (itemPlan, error) {
+	entries, ok := value.([]any)
+	if !ok {
+		return itemPlan{}, invalidItemPatch("/items must remain an array")
+	}
+
+	existing := make(map[string]model.ChecklistItem, len(current))
+	for _, item := range current {
+		existing[item.ID] = item
+	}
+
+	var plan itemPlan
+	final := make([]string, 0, len(entries))
+	kept := make(map[string]bool, len(entries))
+
+	for i, entry := range entries {
+		fields, ok := entry.(map[string]any)
+		if !ok {
+			return itemPlan{}, invalidItemPatch(fmt.Sprintf("/items/%d must be an object", i))
+		}
+		for name := range fields {
+			if name != "id" && name != "text" && name != "done" && name != "position" {
+				return itemPlan{}, invalidItemPatch(fmt.Sprintf("unknown checklist item field '%s'", name))
+			}
+		}
+
+		text, hasText := fields["text"]
+		if hasText {
+			if _, ok := text.(string); !ok {
+				return itemPlan{}, invalidItemPatch(fmt.Sprintf("/items/%d/text must be a string", i))
+			}
+		}
+		done, hasDone := fields["done"]
+		if hasDone {
+			if _, ok := done.(bool); !ok {
+				return itemPlan{}, invalidItemPatch(fmt.Sprintf("/items/%d/done must be a boolean", i))
+			}
+		}
+
+		rawID, hasID := fields["id"]
+		if !hasID {
+			req := dto.CreateChecklistItemRequest{}
+			if hasText {
+				req.Text = text.(string)
+			}
+			if hasDone {
+				req.Done = done.(bool)
+			}
+			if err := validator.ValidateCreateChecklistItemRequest(req); err != nil {
+				return itemPlan{}, status.Error(codes.InvalidArgument, err.Error())
+			}
+			plan.creates = append(plan.creates, req)
+			final = append(final, "")
+			continue
+		}
+
+		itemID, ok := rawID.(string)
+		item, known := existing[itemID]
+		if !ok || !known {
+			return itemPlan{}, invalidItemPatch(fmt.Sprintf("/items/%d has an unknown id; omit the id to add an item", i))
+		}
+		if kept[itemID] {
+			return itemPlan{}, invalidItemPatch(fmt.Sprintf("checklist item '%s' appears more than once", itemID))
+		}
+		kept[itemID] = true
+		final = append(final, itemID)
+
+		if position, ok := fields["position"]; ok {
+			if number, ok := position.(float64); !ok || int(number) != item.Position {
+				return itemPlan{}, invalidItemPatch("reorder checklist items by moving them within /items")
+			}
+		}
+
+		var req dto.UpdateChecklistItemRequest
+		if hasText && text.(string) != item.Text {
+			newText := text.(string)
+			req.Text = &newText
+		}
+		if hasDone && done.(bool) != item.Done {
+			newDone := done.(bool)
+			req.Done = &newDone
+		}
+		if req.Text != nil || req.Done != nil {
+			if err := validator.ValidateUpdateChecklistItemRequest(req); err != nil {
+				return itemPlan{}, status.Error(codes.InvalidArgument, err.Error())
+			}
+			plan.updates = append(plan.updates, itemUpdate{itemID: itemID, req: req})
+		}
+	}
+
+	// After deletes and creates db-service holds the kept items in their
+	// old order followed by the new ones; anything else needs a reorder.
+	var natural []string
+	for _, item := range current {
+		if kept[item.ID] {
+			natural = append(natural, item.ID)
+		} else {
+			plan.deletes = append(plan.deletes, item.ID)
+		}
+	}
+	for range plan.creates {
+		natural = append(natural, "")
+	}
+	if !slices.Equal(natural, final) {
+		plan.order = final
+	}
+
+	return plan, nil
+}
+
+// applyItemPlan runs the item RPCs one at a time. They are not atomic with
+// each other or with the task update; a failure leaves the earlier changes
+// in place.
+func (t *taskService) applyItemPlan(ctx context.Context, taskID string, plan itemPlan) error {
+	for _, itemID := range plan.deletes {
+		if _, err := t.grpcClient.DeleteChecklistItem(ctx, dto.DeleteChecklistItemRequestToProto(taskID, itemID)); err != nil {
+			return fmt.Errorf("failed to delete checklist item: %w", err)
+		}
+	}
+
+	for _, update := range plan.updates {
+		if _, err := t.grpcClient.UpdateChecklistItem(ctx, dto.UpdateChecklistItemRequestToProto(taskID, update.itemID, update.req)); err != nil {
+			return fmt.Errorf("failed to update checklist item: %w", err)
+		}
+	}
+
+	var created []string
+	for _, req := range plan.creates {
+		protoResp, err := t.grpcClient.CreateChecklistItem(ctx, dto.CreateChecklistItemRequestToProto(taskID, req))
+		if err != nil {
+			return fmt.Errorf("failed to create checklist item: %w", err)
+		}
+		created = append(created, protoResp.Item.Id)
+	}
+
+	if plan.order == nil {
+		return nil
+	}
+
+	order := slices.Clone(plan.order)
+	for i := range order {
+		if order[i] == "" {
+			order[i], created = created[0], created[1:]
+		}
+	}
+
+	protoReq := dto.ReorderChecklistItemsRequestToProto(taskID, dto.ReorderChecklistItemsRequest{ItemIDs: order})
+	if _, err := t.grpcClient.ReorderChecklistItems(ctx, protoReq); err != nil {
+		return fmt.Errorf("failed to reorder checklist items: %w", err)
+	}
+
+	return nil
+}
+
+func invalidItemPatch(message string) error {
+	return status.Errorf(codes.InvalidArgument, "Invalid JSON Patch: %s", message)
+}
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/jsonpatch"
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+	pb "github.com/Raisondetr3/checklist-api-service/pkg/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newPatchTestTask() *model.Task {
+	return &model.Task{
+		ID:       "task-1",
+		Title:    "Pack",
+		Priority: model.PriorityMedium,
+		Tags:     []string{},
+		OwnerID:  "alice",
+		Version:  3,
+		Items: []model.ChecklistItem{
+			{ID: "item-1", Text: "Passport", Position: 0},
+			{ID: "item-2", Text: "Charger", Position: 1},
+			{ID: "item-3", Text: "Map", Position: 2},
+		},
+	}
+}
+
+func TestTaskPatchToUpdate(t *testing.T) {
+	done := true
+	renamed := "Cable"
+
+	tests := []struct {
+		name        string
+		patch       string
+		wantMask    []string
+		wantCreates []dto.CreateChecklistItemRequest
+		wantUpdates []itemUpdate
+		wantDeletes []string
+		wantOrder   []string
+		wantCode    codes.Code
+	}{
+		{
+			name:     "task field only",
+			patch:    `[{"op": "replace", "path": "/title", "value": "Unpack"}]`,
+			wantMask: []string{"title"},
+		},
+		{
+			name:        "toggle an item",
+			patch:       `[{"op": "replace", "path": "/items/0/done", "value": true}]`,
+			wantUpdates: []itemUpdate{{itemID: "item-1", req: dto.UpdateChecklistItemRequest{Done: &done}}},
+		},
+		{
+			name:        "rename an item",
+			patch:       `[{"op": "replace", "path": "/items/1/text", "value": "Cable"}]`,
+			wantUpdates: []itemUpdate{{itemID: "item-2", req: dto.UpdateChecklistItemRequest{Text: &renamed}}},
+		},
+		{
+			name:        "append an item",
+			patch:       `[{"op": "add", "path": "/items/-", "value": {"text": "Snacks"}}]`,
+			wantCreates: []dto.CreateChecklistItemRequest{{Text: "Snacks"}},
+		},
+		{
+			name:        "insert an item",
+			patch:       `[{"op": "add", "path": "/items/0", "value": {"text": "Snacks", "done": true}}]`,
+			wantCreates: []dto.CreateChecklistItemRequest{{Text: "Snacks", Done: true}},
+			wantOrder:   []string{"", "item-1", "item-2", "item-3"},
+		},
+		{
+			name:        "remove an item",
+			patch:       `[{"op": "remove", "path": "/items/1"}]`,
+			wantDeletes: []string{"item-2"},
+		},
+		{
+			name:      "move an item",
+			patch:     `[{"op": "move", "from": "/items/2", "path": "/items/0"}]`,
+			wantOrder: []string{"item-3", "item-1", "item-2"},
+		},
+		{
+			name: "task field and items together",
+			patch: `[
+				{"op": "replace", "path": "/title", "value": "Unpack"},
+				{"op": "remove", "path": "/items/2"}
+			]`,
+			wantMask:    []string{"title"},
+			wantDeletes: []string{"item-3"},
+		},
+		{
+			name:  "unchanged items",
+			patch: `[{"op": "replace", "path": "/items/0/done", "value": false}]`,
+		},
+		{
+			name:     "reorder through position",
+			patch:    `[{"op": "replace", "path": "/items/0/position", "value": 2}]`,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "unknown item id",
+			patch:    `[{"op": "add", "path": "/items/-", "value": {"id": "other", "text": "Snacks"}}]`,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "duplicate item",
+			patch:    `[{"op": "copy", "from": "/items/0", "path": "/items/-"}]`,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "new item without text",
+			patch:    `[{"op": "add", "path": "/items/-", "value": {"done": true}}]`,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "unknown item field",
+			patch:    `[{"op": "add", "path": "/items/0/note", "value": "x"}]`,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "items replaced by a non-array",
+			patch:    `[{"op": "replace", "path": "/items", "value": {}}]`,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "read-only field",
+			patch:    `[{"op": "replace", "path": "/progress/done", "value": 3}]`,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "failed test",
+			patch:    `[{"op": "test", "path": "/items/0/text", "value": "Wallet"}]`,
+			wantCode: codes.Aborted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patch []jsonpatch.Operation
+			if err := json.Unmarshal([]byte(tt.patch), &patch); err != nil {
+				t.Fatalf("bad patch: %v", err)
+			}
+
+			req, items, err := taskPatchToUpdate(newPatchTestTask(), patch)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("code = %v, want %v (err: %v)", got, tt.wantCode, err)
+			}
+			if tt.wantCode != codes.OK {
+				return
+			}
+
+			if !slices.Equal(req.UpdateMask, tt.wantMask) {
+				t.Errorf("update mask = %v, want %v", req.UpdateMask, tt.wantMask)
+			}
+			if !reflect.DeepEqual(items.creates, tt.wantCreates) {
+				t.Errorf("creates = %+v, want %+v", items.creates, tt.wantCreates)
+			}
+			if !reflect.DeepEqual(items.updates, tt.wantUpdates) {
+				t.Errorf("updates = %+v, want %+v", items.updates, tt.wantUpdates)
+			}
+			if !slices.Equal(items.deletes, tt.wantDeletes) {
+				t.Errorf("deletes = %v, want %v", items.deletes, tt.wantDeletes)
+			}
+			if !slices.Equal(items.order, tt.wantOrder) {
+				t.Errorf("order = %v, want %v", items.order, tt.wantOrder)
+			}
+		})
+	}
+}
+
+func TestApplyItemPlan(t *testing.T) {
+	tests := []struct {
+		name      string
+		patch     string
+		wantCalls []string
+	}{
+		{
+			name:      "toggle",
+			patch:     `[{"op": "replace", "path": "/items/0/done", "value": true}]`,
+			wantCalls: []string{"update item-1"},
+		},
+		{
+			name:      "append needs no reorder",
+			patch:     `[{"op": "add", "path": "/items/-", "value": {"text": "Snacks"}}]`,
+			wantCalls: []string{"create Snacks as new-0"},
+		},
+		{
+			name: "inserted items are placed with a reorder",
+			patch: `[
+				{"op": "add", "path": "/items/0", "value": {"text": "Snacks"}},
+				{"op": "add", "path": "/items/2", "value": {"text": "Water"}},
+				{"op": "remove", "path": "/items/4"}
+			]`,
+			wantCalls: []string{
+				"delete item-3",
+				"create Snacks as new-1",
+				"create Water as new-2",
+				"reorder new-1,item-1,new-2,item-2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patch []jsonpatch.Operation
+			if err := json.Unmarshal([]byte(tt.patch), &patch); err != nil {
+				t.Fatalf("bad patch: %v", err)
+			}
+			_, items, err := taskPatchToUpdate(newPatchTestTask(), patch)
+			if err != nil {
+				t.Fatalf("taskPatchToUpdate() error = %v", err)
+			}
+
+			fake := newAccessFixture()
+			svc, _ := newBatchTestService(fake)
+			if err := svc.applyItemPlan(withSubject("alice"), "task-1", items); err != nil {
+				t.Fatalf("applyItemPlan() error = %v", err)
+			}
+
+			if !slices.Equal(fake.itemCalls, tt.wantCalls) {
+				t.Errorf("calls = %q, want %q", fake.itemCalls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestPatchTask(t *testing.T) {
+	stale := int64(2)
+
+	tests := []struct {
+		name            string
+		patch           string
+		expectedVersion *int64
+		trashed         bool
+		wantCode        codes.Code
+		wantCalls       []string
+	}{
+		{
+			name:      "items only",
+			patch:     `[{"op": "remove", "path": "/items/0"}]`,
+			wantCalls: []string{"delete item-1"},
+		},
+		{
+			name:     "task field and items together",
+			patch:    `[{"op": "replace", "path": "/title", "value": "Unpack"}, {"op": "remove", "path": "/items/0"}]`,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:            "stale version",
+			patch:           `[{"op": "remove", "path": "/items/0"}]`,
+			expectedVersion: &stale,
+			wantCode:        codes.FailedPrecondition,
+		},
+		{
+			name:     "trashed task",
+			patch:    `[{"op": "remove", "path": "/items/0"}]`,
+			trashed:  true,
+			wantCode: codes.Aborted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patch []jsonpatch.Operation
+			if err := json.Unmarshal([]byte(tt.patch), &patch); err != nil {
+				t.Fatalf("bad patch: %v", err)
+			}
+
+			fake := newAccessFixture()
+			fake.tasks["task-1"] = &pb.Task{
+				Id:      "task-1",
+				Title:   "Pack",
+				OwnerId: "alice",
+				Version: 3,
+				Items:   []*pb.ChecklistItem{{Id: "item-1", Text: "Passport"}, {Id: "item-2", Text: "Charger", Position: 1}},
+			}
+			if tt.trashed {
+				fake.tasks["task-1"].DeletedAt = timestamppb.Now()
+			}
+			svc, _ := newBatchTestService(fake)
+
+			_, err := svc.PatchTask(withSubject("alice"), "task-1", patch, tt.expectedVersion)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("code = %v, want %v (err: %v)", got, tt.wantCode, err)
+			}
+			if !slices.Equal(fake.itemCalls, tt.wantCalls) {
+				t.Errorf("item calls = %q, want %q", fake.itemCalls, tt.wantCalls)
+			}
+		})
+	}
+}
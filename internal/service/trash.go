@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/client"
+	"github.com/Raisondetr3/checklist-api-service/internal/config"
+	"github.com/Raisondetr3/checklist-api-service/internal/history"
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+	"github.com/Raisondetr3/checklist-api-service/pkg/logger"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errTaskInTrash rejects changes to a trashed task. It is a conflict rather
+// than not found, since the caller can still see the task in the trash.
+var errTaskInTrash = status.Error(codes.Aborted, "task is in the trash; restore it first")
+
+// errTrashedTaskNotFound hides trashed tasks from reads of live tasks.
+var errTrashedTaskNotFound = status.Error(codes.NotFound, "task is in the trash")
+
+// GetTaskIncludingTrashed is GetTask for callers that may act on a trashed
+// task, such as a permanent delete resolving If-Match.
+func (t *taskService) GetTaskIncludingTrashed(ctx context.Context, taskID string) (*model.Task, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("task ID is required")
+	}
+
+	return t.access.authorize(ctx, taskID, model.RoleViewer)
+}
+
+func (t *taskService) RestoreTask(ctx context.Context, taskID string) (*model.Task, error) {
+	start := time.Now()
+	operation := "RestoreTask"
+
+	if taskID == "" {
+		err := fmt.Errorf("task ID is required")
+		logger.LogError(ctx, err, operation)
+		return nil, err
+	}
+
+	before, err := t.access.authorize(ctx, taskID, model.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	protoResp, err := t.grpcClient.RestoreTask(ctx, dto.RestoreTaskRequestToProto(taskID))
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.String("task_id", taskID),
+		)
+		return nil, fmt.Errorf("failed to restore task: %w", err)
+	}
+
+	restoredTask := dto.ProtoToModelTask(protoResp.Task)
+
+	slog.InfoContext(ctx, "Task restored successfully",
+		slog.String("operation", operation),
+		slog.String("task_id", restoredTask.ID),
+		slog.Duration("duration", duration),
+	)
+
+	t.publishEvent(ctx, dto.EventTaskRestored, restoredTask)
+	t.recordRevision(ctx, history.OperationRestore, before, restoredTask)
+
+	return restoredTask, nil
+}
+
+// TrashPurger periodically removes tasks that have been in the trash for
+// longer than the retention period.
+type TrashPurger struct {
+	grpcClient client.TaskClient
+	history    history.Store
+	retention  time.Duration
+	interval   time.Duration
+
+	stop    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+}
+
+func NewTrashPurger(taskClient client.TaskClient, historyStore history.Store, cfg config.TrashConfig) *TrashPurger {
+	return &TrashPurger{
+		grpcClient: taskClient,
+		history:    historyStore,
+		retention:  cfg.Retention,
+		interval:   cfg.PurgeInterval,
+		stop:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+}
+
+func (p *TrashPurger) Start() {
+	slog.Info("Trash purger started",
+		slog.Duration("retention", p.retention),
+		slog.Duration("interval", p.interval),
+	)
+
+	go p.run()
+}
+
+func (p *TrashPurger) Stop() {
+	p.once.Do(func() {
+		close(p.stop)
+	})
+	<-p.stopped
+}
+
+func (p *TrashPurger) run() {
+	defer close(p.stopped)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.purge()
+
+		select {
+		case <-ticker.C:
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+func (p *TrashPurger) purge() {
+	start := time.Now()
+	operation := "PurgeDeletedTasks"
+
+	ctx := context.Background()
+	cutoff := start.Add(-p.retention)
+
+	protoResp, err := p.grpcClient.PurgeDeletedTasks(ctx, dto.PurgeDeletedTasksRequestToProto(cutoff))
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.LogError(ctx, err, operation,
+			slog.Duration("duration", duration),
+			slog.Time("deleted_before", cutoff),
+		)
+		return
+	}
+
+	if protoResp.Purged == 0 {
+		return
+	}
+
+	slog.InfoContext(ctx, "Trashed tasks purged",
+		slog.String("operation", operation),
+		slog.Int("purged", int(protoResp.Purged)),
+		slog.Time("deleted_before", cutoff),
+		slog.Duration("duration", duration),
+	)
+
+	for _, protoTask := range protoResp.Tasks {
+		appendRevision(ctx, p.history, systemActor, history.OperationPurge, dto.ProtoToModelTask(protoTask), nil)
+	}
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/auth"
+	"github.com/Raisondetr3/checklist-api-service/internal/config"
+	"github.com/Raisondetr3/checklist-api-service/internal/history"
+	"github.com/Raisondetr3/checklist-api-service/internal/jsonpatch"
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+	pb "github.com/Raisondetr3/checklist-api-service/pkg/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestMutationsRejectTrashedTasks(t *testing.T) {
+	title := "Unpack"
+
+	tests := []struct {
+		name     string
+		call     func(ctx context.Context, fake *fakeTaskClient) error
+		wantCode codes.Code
+	}{
+		{
+			name: "update",
+			call: func(ctx context.Context, fake *fakeTaskClient) error {
+				svc, _ := newBatchTestService(fake)
+				_, err := svc.UpdateTask(ctx, "trashed", dto.UpdateTaskRequest{Title: &title})
+				return err
+			},
+			wantCode: codes.Aborted,
+		},
+		{
+			name: "json patch",
+			call: func(ctx context.Context, fake *fakeTaskClient) error {
+				svc, _ := newBatchTestService(fake)
+				_, err := svc.PatchTask(ctx, "trashed", []jsonpatch.Operation{{Op: jsonpatch.OpReplace, Path: "/title", Value: []byte(`"Unpack"`)}}, nil)
+				return err
+			},
+			wantCode: codes.Aborted,
+		},
+		{
+			name: "move to trash again",
+			call: func(ctx context.Context, fake *fakeTaskClient) error {
+				svc, _ := newBatchTestService(fake)
+				return svc.DeleteTask(ctx, "trashed", nil, false)
+			},
+			wantCode: codes.Aborted,
+		},
+		{
+			name: "permanent delete",
+			call: func(ctx context.Context, fake *fakeTaskClient) error {
+				svc, _ := newBatchTestService(fake)
+				return svc.DeleteTask(ctx, "trashed", nil, true)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "batch update",
+			call: func(ctx context.Context, fake *fakeTaskClient) error {
+				svc, _ := newBatchTestService(fake)
+				results, err := svc.BatchTasks(ctx, []dto.BatchTaskOperation{updateOp("trashed", title)}, false)
+				if err != nil {
+					return err
+				}
+				return results[0].Err
+			},
+			wantCode: codes.Aborted,
+		},
+		{
+			name: "batch permanent delete",
+			call: func(ctx context.Context, fake *fakeTaskClient) error {
+				svc, _ := newBatchTestService(fake)
+				op := deleteOp("trashed")
+				op.Permanent = true
+				results, err := svc.BatchTasks(ctx, []dto.BatchTaskOperation{op}, false)
+				if err != nil {
+					return err
+				}
+				return results[0].Err
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "add checklist item",
+			call: func(ctx context.Context, fake *fakeTaskClient) error {
+				_, err := NewChecklistItemService(fake, history.NewMemoryStore(10, time.Hour)).CreateItem(ctx, "trashed", dto.CreateChecklistItemRequest{Text: "Map"})
+				return err
+			},
+			wantCode: codes.Aborted,
+		},
+		{
+			name: "share",
+			call: func(ctx context.Context, fake *fakeTaskClient) error {
+				_, err := NewShareService(fake).ShareTask(ctx, "trashed", dto.ShareTaskRequest{UserID: "bob", Role: "viewer"})
+				return err
+			},
+			wantCode: codes.Aborted,
+		},
+	}
+
+	principals := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{name: "owner", ctx: withSubject("alice")},
+		{name: "no principal", ctx: context.Background()},
+	}
+
+	for _, tt := range tests {
+		for _, principal := range principals {
+			t.Run(tt.name+"/"+principal.name, func(t *testing.T) {
+				fake := newAccessFixture()
+				fake.tasks["trashed"] = &pb.Task{Id: "trashed", Title: "Pack", OwnerId: "alice", DeletedAt: timestamppb.Now()}
+
+				err := tt.call(principal.ctx, fake)
+				if got := status.Code(err); got != tt.wantCode {
+					t.Fatalf("code = %v, want %v (err: %v)", got, tt.wantCode, err)
+				}
+			})
+		}
+	}
+}
+
+func TestGetTaskHidesTrashedTasks(t *testing.T) {
+	fake := newAccessFixture()
+	fake.tasks["trashed"] = &pb.Task{Id: "trashed", Title: "Pack", OwnerId: "alice", DeletedAt: timestamppb.Now()}
+	svc, _ := newBatchTestService(fake)
+
+	_, err := svc.GetTask(withSubject("alice"), "trashed")
+	if got := status.Code(err); got != codes.NotFound {
+		t.Errorf("code = %v, want %v (err: %v)", got, codes.NotFound, err)
+	}
+}
+
+func TestFilterTasksSeparatesTrash(t *testing.T) {
+	now := time.Now()
+	tasks := []*model.Task{{ID: "live"}, {ID: "trashed", DeletedAt: &now}}
+
+	tests := []struct {
+		name    string
+		deleted bool
+		want    string
+	}{
+		{name: "live tasks", deleted: false, want: "live"},
+		{name: "trash", deleted: true, want: "trashed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterTasks(tasks, dto.ListTasksRequest{Deleted: tt.deleted})
+			if len(got) != 1 || got[0].ID != tt.want {
+				t.Errorf("filterTasks() = %v, want only %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrashPurgerRecordsPurges(t *testing.T) {
+	fake := newAccessFixture()
+	fake.tasks["expired"] = &pb.Task{Id: "expired", Title: "Pack", OwnerId: "alice", Version: 4, DeletedAt: timestamppb.New(time.Now().Add(-48 * time.Hour))}
+	fake.tasks["recent"] = &pb.Task{Id: "recent", Title: "Unpack", OwnerId: "alice", DeletedAt: timestamppb.Now()}
+	store := history.NewMemoryStore(10, time.Hour)
+
+	purger := NewTrashPurger(fake, store, config.TrashConfig{Retention: 24 * time.Hour, PurgeInterval: time.Hour})
+	purger.purge()
+
+	revisions, _ := store.List(context.Background(), "expired")
+	if len(revisions) != 1 {
+		t.Fatalf("revisions = %+v, want one purge", revisions)
+	}
+	revision := revisions[0]
+	if revision.Operation != history.OperationPurge || revision.Actor != systemActor || revision.Version != 4 {
+		t.Errorf("revision = %+v, want a purge by %q at version 4", revision, systemActor)
+	}
+
+	if revisions, _ := store.List(context.Background(), "recent"); len(revisions) != 0 {
+		t.Errorf("task still in retention has revisions %+v", revisions)
+	}
+}
+
+func TestGetTaskHistoryOfPurgedTask(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		taskID   string
+		wantCode codes.Code
+		wantLen  int
+	}{
+		{name: "admin reads purged history", ctx: withSubject("root", auth.ScopeAdmin), taskID: "purged", wantLen: 2},
+		{name: "former owner cannot", ctx: withSubject("alice"), taskID: "purged", wantCode: codes.NotFound},
+		{name: "admin on a task without history", ctx: withSubject("root", auth.ScopeAdmin), taskID: "missing", wantCode: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newAccessFixture()
+			svc, store := newBatchTestService(fake)
+
+			task := &model.Task{ID: "purged", Title: "Pack", OwnerID: "alice"}
+			appendRevision(context.Background(), store, "alice", history.OperationCreate, nil, task)
+			appendRevision(context.Background(), store, "alice", history.OperationPurge, task, nil)
+
+			revisions, err := svc.GetTaskHistory(tt.ctx, tt.taskID)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("code = %v, want %v (err: %v)", got, tt.wantCode, err)
+			}
+			if len(revisions) != tt.wantLen {
+				t.Errorf("revisions = %d, want %d", len(revisions), tt.wantLen)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/auth"
+	"github.com/Raisondetr3/checklist-api-service/internal/validator"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+
+	"github.com/gorilla/mux"
+)
+
+type APIKeyHandlers struct {
+	store auth.APIKeyStore
+}
+
+func NewAPIKeyHandlers(store auth.APIKeyStore) *APIKeyHandlers {
+	return &APIKeyHandlers{
+		store: store,
+	}
+}
+
+func (h *APIKeyHandlers) HandleCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req dto.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validator.ValidateCreateAPIKeyRequest(req); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	key, secret, err := h.store.Create(req.Name, req.Scopes)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create API key", slog.String("error", err.Error()))
+		WriteErrorResponse(w, "Failed to create API key", http.StatusInternalServerError)
+		return
+	}
+
+	response := dto.CreateAPIKeyResponse{
+		APIKeyResponse: dto.APIKeyToResponse(key),
+		Key:            secret,
+	}
+	WriteJSONResponse(w, http.StatusCreated, response)
+
+	slog.InfoContext(ctx, "API key created via HTTP",
+		slog.String("key_id", key.ID),
+		slog.String("name", key.Name),
+		slog.Any("scopes", key.Scopes),
+		slog.String("created_by", auth.SubjectFromContext(ctx)),
+	)
+}
+
+func (h *APIKeyHandlers) HandleListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	WriteJSONResponse(w, http.StatusOK, dto.APIKeysToListResponse(h.store.List()))
+}
+
+func (h *APIKeyHandlers) HandleRevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	keyID := mux.Vars(r)["id"]
+	if err := validator.ValidateAPIKeyID(keyID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	key, err := h.store.Revoke(keyID)
+	if errors.Is(err, auth.ErrAPIKeyNotFound) {
+		WriteErrorResponse(w, "API key not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to revoke API key",
+			slog.String("key_id", keyID),
+			slog.String("error", err.Error()),
+		)
+		WriteErrorResponse(w, "Failed to revoke API key", http.StatusInternalServerError)
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, dto.APIKeyToResponse(key))
+
+	slog.InfoContext(ctx, "API key revoked via HTTP",
+		slog.String("key_id", key.ID),
+		slog.String("revoked_by", auth.SubjectFromContext(ctx)),
+	)
+}
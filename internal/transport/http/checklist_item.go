@@ -0,0 +1,203 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/service"
+	"github.com/Raisondetr3/checklist-api-service/internal/validator"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+
+	"github.com/gorilla/mux"
+)
+
+type ChecklistItemHandlers struct {
+	itemService service.ChecklistItemService
+}
+
+func NewChecklistItemHandlers(itemService service.ChecklistItemService) *ChecklistItemHandlers {
+	return &ChecklistItemHandlers{
+		itemService: itemService,
+	}
+}
+
+func (h *ChecklistItemHandlers) HandleListItems(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := mux.Vars(r)["id"]
+	if err := validator.ValidateTaskID(taskID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	items, err := h.itemService.ListItems(ctx, taskID)
+	if err != nil {
+		writeServiceError(w, err, "Failed to get checklist items")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, dto.ChecklistItemsToListResponse(items))
+
+	slog.InfoContext(ctx, "Checklist items retrieved via HTTP",
+		slog.String("task_id", taskID),
+		slog.Int("count", len(items)),
+	)
+}
+
+func (h *ChecklistItemHandlers) HandleGetItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, itemID, ok := itemPathParams(w, r)
+	if !ok {
+		return
+	}
+
+	item, err := h.itemService.GetItem(ctx, taskID, itemID)
+	if err != nil {
+		writeServiceError(w, err, "Failed to get checklist item")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, dto.ChecklistItemToResponse(*item))
+}
+
+func (h *ChecklistItemHandlers) HandleCreateItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := mux.Vars(r)["id"]
+	if err := validator.ValidateTaskID(taskID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req dto.CreateChecklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validator.ValidateCreateChecklistItemRequest(req); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	item, err := h.itemService.CreateItem(ctx, taskID, req)
+	if err != nil {
+		writeServiceError(w, err, "Failed to create checklist item")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusCreated, dto.ChecklistItemToResponse(*item))
+
+	slog.InfoContext(ctx, "Checklist item created via HTTP",
+		slog.String("task_id", taskID),
+		slog.String("item_id", item.ID),
+	)
+}
+
+func (h *ChecklistItemHandlers) HandleUpdateItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, itemID, ok := itemPathParams(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateChecklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validator.ValidateUpdateChecklistItemRequest(req); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	item, err := h.itemService.UpdateItem(ctx, taskID, itemID, req)
+	if err != nil {
+		writeServiceError(w, err, "Failed to update checklist item")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, dto.ChecklistItemToResponse(*item))
+
+	slog.InfoContext(ctx, "Checklist item updated via HTTP",
+		slog.String("task_id", taskID),
+		slog.String("item_id", itemID),
+	)
+}
+
+func (h *ChecklistItemHandlers) HandleDeleteItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, itemID, ok := itemPathParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.itemService.DeleteItem(ctx, taskID, itemID); err != nil {
+		writeServiceError(w, err, "Failed to delete checklist item")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, dto.DeleteTaskResponse{Success: true})
+
+	slog.InfoContext(ctx, "Checklist item deleted via HTTP",
+		slog.String("task_id", taskID),
+		slog.String("item_id", itemID),
+	)
+}
+
+func (h *ChecklistItemHandlers) HandleReorderItems(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := mux.Vars(r)["id"]
+	if err := validator.ValidateTaskID(taskID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req dto.ReorderChecklistItemsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validator.ValidateReorderChecklistItemsRequest(req); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	items, err := h.itemService.ReorderItems(ctx, taskID, req.ItemIDs)
+	if err != nil {
+		writeServiceError(w, err, "Failed to reorder checklist items")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, dto.ChecklistItemsToListResponse(items))
+
+	slog.InfoContext(ctx, "Checklist items reordered via HTTP",
+		slog.String("task_id", taskID),
+		slog.Int("count", len(items)),
+	)
+}
+
+func itemPathParams(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+	itemID := vars["itemId"]
+
+	if err := validator.ValidateTaskID(taskID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return "", "", false
+	}
+
+	if err := validator.ValidateItemID(itemID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return "", "", false
+	}
+
+	return taskID, itemID, true
+}
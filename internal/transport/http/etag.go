@@ -0,0 +1,150 @@
+package http
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/internal/validator"
+)
+
+// taskETag hashes the task's id, version and update time plus a revision of
+// its checklist items and shares, which change without a version bump.
+func taskETag(task *model.Task) string {
+	h := fnv.New128a()
+	writeTaskFingerprint(h, task)
+
+	return `"` + hex.EncodeToString(h.Sum(nil)) + `"`
+}
+
+// writeTaskFingerprint writes the fields that identify a revision of the
+// task. It avoids encoding the representation so a conditional GET for a
+// page of tasks stays cheap.
+func writeTaskFingerprint(h hash.Hash, task *model.Task) {
+	buf := make([]byte, 0, 128)
+	buf = append(buf, task.ID...)
+	buf = append(buf, 0)
+	buf = strconv.AppendInt(buf, task.Version, 10)
+	buf = append(buf, 0)
+	buf = strconv.AppendInt(buf, task.UpdatedAt.UnixNano(), 10)
+	buf = append(buf, 0)
+	buf = strconv.AppendBool(buf, task.DeletedAt != nil)
+	buf = append(buf, 0)
+
+	for _, item := range task.Items {
+		buf = append(buf, item.ID...)
+		buf = append(buf, 0)
+		buf = append(buf, item.Text...)
+		buf = append(buf, 0)
+		buf = strconv.AppendBool(buf, item.Done)
+		buf = strconv.AppendInt(buf, int64(item.Position), 10)
+		buf = append(buf, 0)
+	}
+	for _, share := range task.Shares {
+		buf = append(buf, share.UserID...)
+		buf = append(buf, 0)
+		buf = append(buf, share.Role...)
+		buf = append(buf, 0)
+	}
+
+	h.Write(buf)
+}
+
+// setTaskETag sets the task's only validator. Last-Modified is not sent:
+// item and share changes leave UpdatedAt alone and carry no timestamps of
+// their own, so no date would reliably move with the representation, and
+// If-Modified-Since is not supported.
+func setTaskETag(w http.ResponseWriter, task *model.Task) {
+	w.Header().Set("ETag", taskETag(task))
+}
+
+// parseIfMatch resolves If-Match against the current task and returns the
+// version the update must apply to, so db-service rejects a write that
+// races with another one. includeTrashed lets it resolve a task in the
+// trash, for requests that may target one. On failure it writes the error
+// response and returns false.
+func (h *TaskHandlers) parseIfMatch(w http.ResponseWriter, r *http.Request, taskID string, includeTrashed bool) (*int64, bool) {
+	tags, err := validator.ValidateIfMatchHeader(r.Header.Get("If-Match"))
+	if errors.Is(err, validator.ErrPreconditionFailed) {
+		WriteErrorResponse(w, err.Error(), http.StatusPreconditionFailed)
+		return nil, false
+	}
+	if err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	if tags == nil {
+		return nil, true
+	}
+
+	getTask := h.taskService.GetTask
+	if includeTrashed {
+		getTask = h.taskService.GetTaskIncludingTrashed
+	}
+
+	task, err := getTask(r.Context(), taskID)
+	if err != nil {
+		h.handleServiceError(w, err, "Failed to get task")
+		return nil, false
+	}
+
+	if !slices.Contains(tags, taskETag(task)) {
+		WriteErrorResponse(w, validator.ErrPreconditionFailed.Error(), http.StatusPreconditionFailed)
+		return nil, false
+	}
+
+	return &task.Version, true
+}
+
+// tasksETag derives a weak entity tag for a page of tasks from each task's
+// fingerprint plus the paging state.
+func tasksETag(tasks []*model.Task, totalCount int, nextCursor string) string {
+	h := fnv.New128a()
+	for _, task := range tasks {
+		writeTaskFingerprint(h, task)
+	}
+	fmt.Fprintf(h, "total=%d;next=%s", totalCount, nextCursor)
+
+	return `W/"` + hex.EncodeToString(h.Sum(nil)) + `"`
+}
+
+// writeConditionalJSON sets the ETag and answers 304 Not Modified when the
+// client's cached copy is current, skipping JSON encoding entirely.
+func writeConditionalJSON(w http.ResponseWriter, r *http.Request, etag string, data interface{}) {
+	w.Header().Set("ETag", etag)
+
+	if notModified(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, data)
+}
+
+// notModified evaluates If-None-Match. If-Modified-Since is ignored, since
+// no Last-Modified is ever sent; see setTaskETag.
+func notModified(r *http.Request, etag string) bool {
+	ifNoneMatch := r.Header.Get("If-None-Match")
+	return ifNoneMatch != "" && etagListMatches(ifNoneMatch, etag)
+}
+
+// etagListMatches uses the weak comparison If-None-Match calls for.
+func etagListMatches(header, etag string) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
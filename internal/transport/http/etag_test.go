@@ -0,0 +1,243 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/internal/service"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeTaskService serves GetTask from memory and, like the real service,
+// hides trashed tasks from it. Methods a test does not stub panic through
+// the embedded nil interface.
+type fakeTaskService struct {
+	service.TaskService
+
+	tasks map[string]*model.Task
+}
+
+func (s *fakeTaskService) GetTask(ctx context.Context, taskID string) (*model.Task, error) {
+	task, err := s.GetTaskIncludingTrashed(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.DeletedAt != nil {
+		return nil, status.Error(codes.NotFound, "task is in the trash")
+	}
+	return task, nil
+}
+
+func (s *fakeTaskService) GetTaskIncludingTrashed(ctx context.Context, taskID string) (*model.Task, error) {
+	task, ok := s.tasks[taskID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "task not found")
+	}
+	return task, nil
+}
+
+var etagTestTime = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func newETagTestTask() *model.Task {
+	return &model.Task{
+		ID:        "task-1",
+		Title:     "Pack",
+		Priority:  model.PriorityMedium,
+		Tags:      []string{"trip"},
+		OwnerID:   "alice",
+		Version:   3,
+		CreatedAt: etagTestTime,
+		UpdatedAt: etagTestTime,
+		Items: []model.ChecklistItem{
+			{ID: "item-1", Text: "Passport", Position: 0},
+			{ID: "item-2", Text: "Charger", Position: 1},
+		},
+	}
+}
+
+func TestTaskETag(t *testing.T) {
+	tests := []struct {
+		name        string
+		mutate      func(task *model.Task)
+		wantChanged bool
+	}{
+		{name: "unchanged", mutate: func(task *model.Task) {}},
+		{name: "version bump", mutate: func(task *model.Task) { task.Version++ }, wantChanged: true},
+		{name: "updated at", mutate: func(task *model.Task) { task.UpdatedAt = task.UpdatedAt.Add(time.Second) }, wantChanged: true},
+		{name: "trashed", mutate: func(task *model.Task) { task.DeletedAt = &etagTestTime }, wantChanged: true},
+		{name: "item reordered", mutate: func(task *model.Task) { task.Items[0].Position, task.Items[1].Position = 1, 0 }, wantChanged: true},
+		{name: "item toggled", mutate: func(task *model.Task) { task.Items[0].Done = true }, wantChanged: true},
+		{name: "item renamed", mutate: func(task *model.Task) { task.Items[1].Text = "Cable" }, wantChanged: true},
+		{
+			name: "item added",
+			mutate: func(task *model.Task) {
+				task.Items = append(task.Items, model.ChecklistItem{ID: "item-3", Text: "Map", Position: 2})
+			},
+			wantChanged: true,
+		},
+		{
+			name: "share added",
+			mutate: func(task *model.Task) {
+				task.Shares = append(task.Shares, model.Share{UserID: "bob", Role: model.RoleViewer})
+			},
+			wantChanged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := taskETag(newETagTestTask())
+
+			task := newETagTestTask()
+			tt.mutate(task)
+			after := taskETag(task)
+
+			if changed := before != after; changed != tt.wantChanged {
+				t.Errorf("ETag changed = %v, want %v (%s -> %s)", changed, tt.wantChanged, before, after)
+			}
+		})
+	}
+}
+
+func TestTasksETagTracksItems(t *testing.T) {
+	before := tasksETag([]*model.Task{newETagTestTask()}, 1, "")
+
+	task := newETagTestTask()
+	task.Items[0].Done = true
+	after := tasksETag([]*model.Task{task}, 1, "")
+
+	if before == after {
+		t.Errorf("page ETag did not change after an item was toggled: %s", before)
+	}
+}
+
+func TestHandleGetTaskConditional(t *testing.T) {
+	task := newETagTestTask()
+	etag := taskETag(task)
+
+	tests := []struct {
+		name       string
+		headers    map[string]string
+		wantStatus int
+	}{
+		{name: "no validators", wantStatus: http.StatusOK},
+		{name: "matching etag", headers: map[string]string{"If-None-Match": etag}, wantStatus: http.StatusNotModified},
+		{name: "weak form matches", headers: map[string]string{"If-None-Match": "W/" + etag}, wantStatus: http.StatusNotModified},
+		{name: "etag in list", headers: map[string]string{"If-None-Match": `"stale", ` + etag}, wantStatus: http.StatusNotModified},
+		{name: "any", headers: map[string]string{"If-None-Match": "*"}, wantStatus: http.StatusNotModified},
+		{name: "stale etag", headers: map[string]string{"If-None-Match": `"stale"`}, wantStatus: http.StatusOK},
+		{
+			// No Last-Modified is sent, so a date never proves the cached
+			// copy is current.
+			name:       "if-modified-since is ignored",
+			headers:    map[string]string{"If-Modified-Since": etagTestTime.Add(time.Hour).Format(http.TimeFormat)},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "if-modified-since in the past is ignored too",
+			headers:    map[string]string{"If-Modified-Since": etagTestTime.Add(-time.Hour).Format(http.TimeFormat)},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "etag is used alongside a date",
+			headers: map[string]string{
+				"If-None-Match":     etag,
+				"If-Modified-Since": etagTestTime.Add(-time.Hour).Format(http.TimeFormat),
+			},
+			wantStatus: http.StatusNotModified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewTaskHandlers(&fakeTaskService{tasks: map[string]*model.Task{task.ID: task}})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/"+task.ID, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": task.ID})
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			rec := httptest.NewRecorder()
+			h.HandleGetTask(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("ETag"); got != etag {
+				t.Errorf("ETag = %q, want %q", got, etag)
+			}
+			if got := rec.Header().Get("Last-Modified"); got != "" {
+				t.Errorf("Last-Modified = %q, want it unset", got)
+			}
+			if tt.wantStatus == http.StatusNotModified && rec.Body.Len() != 0 {
+				t.Errorf("304 response has a body: %q", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestParseIfMatch(t *testing.T) {
+	task := newETagTestTask()
+	etag := taskETag(task)
+
+	trashed := newETagTestTask()
+	trashed.ID = "trashed"
+	trashed.DeletedAt = &etagTestTime
+	trashedETag := taskETag(trashed)
+
+	tests := []struct {
+		name           string
+		taskID         string
+		ifMatch        string
+		includeTrashed bool
+		wantOK         bool
+		wantVersion    *int64
+		wantStatus     int
+	}{
+		{name: "absent", taskID: task.ID, wantOK: true},
+		{name: "any", taskID: task.ID, ifMatch: "*", wantOK: true},
+		{name: "current", taskID: task.ID, ifMatch: etag, wantOK: true, wantVersion: &task.Version},
+		{name: "current in list", taskID: task.ID, ifMatch: `"stale", ` + etag, wantOK: true, wantVersion: &task.Version},
+		{name: "stale", taskID: task.ID, ifMatch: `"stale"`, wantStatus: http.StatusPreconditionFailed},
+		{name: "weak only", taskID: task.ID, ifMatch: "W/" + etag, wantStatus: http.StatusPreconditionFailed},
+		{name: "malformed", taskID: task.ID, ifMatch: "3", wantStatus: http.StatusBadRequest},
+		{name: "missing task", taskID: "missing", ifMatch: etag, wantStatus: http.StatusNotFound},
+		{name: "trashed task", taskID: trashed.ID, ifMatch: trashedETag, wantStatus: http.StatusNotFound},
+		{name: "trashed task included", taskID: trashed.ID, ifMatch: trashedETag, includeTrashed: true, wantOK: true, wantVersion: &trashed.Version},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewTaskHandlers(&fakeTaskService{tasks: map[string]*model.Task{task.ID: task, trashed.ID: trashed}})
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/tasks/"+tt.taskID, nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			rec := httptest.NewRecorder()
+			version, ok := h.parseIfMatch(rec, req, tt.taskID, tt.includeTrashed)
+
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				if rec.Code != tt.wantStatus {
+					t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+				}
+				return
+			}
+			if (version == nil) != (tt.wantVersion == nil) || (version != nil && *version != *tt.wantVersion) {
+				t.Errorf("version = %v, want %v", version, tt.wantVersion)
+			}
+		})
+	}
+}
@@ -4,11 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+	"mime"
 	"net/http"
+	"strings"
 
+	"github.com/Raisondetr3/checklist-api-service/internal/auth"
 	"github.com/Raisondetr3/checklist-api-service/internal/config"
+	"github.com/Raisondetr3/checklist-api-service/internal/idempotency"
 	"github.com/Raisondetr3/checklist-api-service/internal/service"
+	"github.com/Raisondetr3/checklist-api-service/internal/transport/http/middleware"
 	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+	apiErrors "github.com/Raisondetr3/checklist-api-service/pkg/errors"
 
 	"github.com/gorilla/mux"
 )
@@ -16,13 +22,30 @@ import (
 type HTTPHandlers struct {
 	config         *config.Config
 	taskHandlers   *TaskHandlers
+	itemHandlers   *ChecklistItemHandlers
+	listHandlers   *ListHandlers
+	shareHandlers  *ShareHandlers
 	healthHandlers *HealthHandlers
+	apiKeyHandlers *APIKeyHandlers
+	authenticator  *middleware.Authenticator
+	idempotency    *idempotency.Store
 }
 
-func NewHTTPHandlers(cfg *config.Config, taskService service.TaskService, healthService service.HealthService) *HTTPHandlers {
+func NewHTTPHandlers(cfg *config.Config, taskService service.TaskService, itemService service.ChecklistItemService, listService service.ListService, shareService service.ShareService, healthService service.HealthService, authenticator *middleware.Authenticator, apiKeys auth.APIKeyStore) *HTTPHandlers {
+	var apiKeyHandlers *APIKeyHandlers
+	if apiKeys != nil {
+		apiKeyHandlers = NewAPIKeyHandlers(apiKeys)
+	}
+
 	return &HTTPHandlers{
 		config:         cfg,
+		apiKeyHandlers: apiKeyHandlers,
+		authenticator:  authenticator,
+		idempotency:    idempotency.NewStore(cfg.Idempotency.TTL, cfg.Idempotency.MaxEntries),
 		taskHandlers:   NewTaskHandlers(taskService),
+		itemHandlers:   NewChecklistItemHandlers(itemService),
+		listHandlers:   NewListHandlers(listService),
+		shareHandlers:  NewShareHandlers(shareService),
 		healthHandlers: NewHealthHandlers(healthService),
 	}
 }
@@ -32,12 +55,66 @@ func (h *HTTPHandlers) SetupRoutes(router *mux.Router) {
 	router.HandleFunc("/", h.RootHandler).Methods("GET")
 
 	v1 := router.PathPrefix("/api/v1").Subrouter()
-	
-	v1.HandleFunc("/tasks", h.taskHandlers.HandleCreateTask).Methods("POST")
-	v1.HandleFunc("/tasks", h.taskHandlers.HandleGetTasks).Methods("GET")
-	v1.HandleFunc("/tasks/{id}", h.taskHandlers.HandleGetTask).Methods("GET")
-	v1.HandleFunc("/tasks/{id}", h.taskHandlers.HandleUpdateTask).Methods("PUT", "PATCH")
-	v1.HandleFunc("/tasks/{id}", h.taskHandlers.HandleDeleteTask).Methods("DELETE")
+	if h.config.RateLimit.Enabled && h.authenticator != nil {
+		// Throttle by address first so credential guessing is limited too.
+		v1.Use(middleware.NewIPRateLimiter(h.config.RateLimit).Middleware)
+	}
+	if h.authenticator != nil {
+		v1.Use(h.authenticator.Middleware)
+	}
+	if h.config.RateLimit.Enabled {
+		v1.Use(middleware.NewRateLimiter(h.config.RateLimit).Middleware)
+	}
+
+	v1.HandleFunc("/tasks", h.scoped(auth.ScopeTasksWrite, middleware.Idempotent(h.idempotency, h.taskHandlers.HandleCreateTask))).Methods("POST")
+	v1.HandleFunc("/tasks", h.scoped(auth.ScopeTasksRead, h.taskHandlers.HandleGetTasks)).Methods("GET")
+	v1.HandleFunc("/tasks/export", h.scoped(auth.ScopeTasksRead, h.taskHandlers.HandleExportTasks)).Methods("GET")
+	v1.HandleFunc("/tasks:batch", h.scoped(auth.ScopeTasksWrite, middleware.Idempotent(h.idempotency, h.taskHandlers.HandleBatchTasks))).Methods("POST")
+	v1.HandleFunc("/tasks/{id}:restore", h.scoped(auth.ScopeTasksWrite, h.taskHandlers.HandleRestoreTask)).Methods("POST")
+	v1.HandleFunc("/tasks/{id}", h.scoped(auth.ScopeTasksRead, h.taskHandlers.HandleGetTask)).Methods("GET")
+	v1.HandleFunc("/tasks/{id}", h.scoped(auth.ScopeTasksWrite, h.taskHandlers.HandleReplaceTask)).Methods("PUT")
+	v1.HandleFunc("/tasks/{id}", h.scoped(auth.ScopeTasksWrite, h.taskHandlers.HandleUpdateTask)).Methods("PATCH")
+	v1.HandleFunc("/tasks/{id}", h.scoped(auth.ScopeTasksDelete, h.taskHandlers.HandleDeleteTask)).Methods("DELETE")
+
+	v1.HandleFunc("/tasks/{id}/history", h.scoped(auth.ScopeTasksRead, h.taskHandlers.HandleGetTaskHistory)).Methods("GET")
+
+	v1.HandleFunc("/tasks/{id}/items", h.scoped(auth.ScopeTasksRead, h.itemHandlers.HandleListItems)).Methods("GET")
+	v1.HandleFunc("/tasks/{id}/items", h.scoped(auth.ScopeTasksWrite, h.itemHandlers.HandleCreateItem)).Methods("POST")
+	v1.HandleFunc("/tasks/{id}/items:reorder", h.scoped(auth.ScopeTasksWrite, h.itemHandlers.HandleReorderItems)).Methods("POST")
+	v1.HandleFunc("/tasks/{id}/items/{itemId}", h.scoped(auth.ScopeTasksRead, h.itemHandlers.HandleGetItem)).Methods("GET")
+	v1.HandleFunc("/tasks/{id}/items/{itemId}", h.scoped(auth.ScopeTasksWrite, h.itemHandlers.HandleUpdateItem)).Methods("PUT", "PATCH")
+	v1.HandleFunc("/tasks/{id}/items/{itemId}", h.scoped(auth.ScopeTasksWrite, h.itemHandlers.HandleDeleteItem)).Methods("DELETE")
+
+	v1.HandleFunc("/tasks/{id}/shares", h.scoped(auth.ScopeTasksRead, h.shareHandlers.HandleListShares)).Methods("GET")
+	v1.HandleFunc("/tasks/{id}/shares", h.scoped(auth.ScopeTasksWrite, h.shareHandlers.HandleShareTask)).Methods("POST")
+	v1.HandleFunc("/tasks/{id}/shares/{userId}", h.scoped(auth.ScopeTasksWrite, h.shareHandlers.HandleUnshareTask)).Methods("DELETE")
+
+	v1.HandleFunc("/lists", h.scoped(auth.ScopeTasksRead, h.listHandlers.HandleGetLists)).Methods("GET")
+	v1.HandleFunc("/lists", h.scoped(auth.ScopeTasksWrite, h.listHandlers.HandleCreateList)).Methods("POST")
+	v1.HandleFunc("/lists/{id}", h.scoped(auth.ScopeTasksRead, h.listHandlers.HandleGetList)).Methods("GET")
+	v1.HandleFunc("/lists/{id}", h.scoped(auth.ScopeTasksWrite, h.listHandlers.HandleUpdateList)).Methods("PUT", "PATCH")
+	v1.HandleFunc("/lists/{id}", h.scoped(auth.ScopeTasksDelete, h.listHandlers.HandleDeleteList)).Methods("DELETE")
+	v1.HandleFunc("/lists/{id}/tasks", h.scoped(auth.ScopeTasksRead, h.listHandlers.HandleGetListTasks)).Methods("GET")
+	v1.HandleFunc("/lists/{id}/shares", h.scoped(auth.ScopeTasksRead, h.shareHandlers.HandleListListShares)).Methods("GET")
+	v1.HandleFunc("/lists/{id}/shares", h.scoped(auth.ScopeTasksWrite, h.shareHandlers.HandleShareList)).Methods("POST")
+	v1.HandleFunc("/lists/{id}/shares/{userId}", h.scoped(auth.ScopeTasksWrite, h.shareHandlers.HandleUnshareList)).Methods("DELETE")
+
+	v1.HandleFunc("/tags", h.scoped(auth.ScopeTasksRead, h.taskHandlers.HandleGetTags)).Methods("GET")
+	v1.HandleFunc("/trash", h.scoped(auth.ScopeTasksRead, h.taskHandlers.HandleGetTrash)).Methods("GET")
+
+	if h.apiKeyHandlers != nil {
+		v1.HandleFunc("/admin/api-keys", h.scoped(auth.ScopeAdmin, h.apiKeyHandlers.HandleListAPIKeys)).Methods("GET")
+		v1.HandleFunc("/admin/api-keys", h.scoped(auth.ScopeAdmin, h.apiKeyHandlers.HandleCreateAPIKey)).Methods("POST")
+		v1.HandleFunc("/admin/api-keys/{id}", h.scoped(auth.ScopeAdmin, h.apiKeyHandlers.HandleRevokeAPIKey)).Methods("DELETE")
+	}
+}
+
+// scoped enforces the route's scope when authentication is enabled.
+func (h *HTTPHandlers) scoped(scope string, handler http.HandlerFunc) http.HandlerFunc {
+	if h.authenticator == nil {
+		return handler
+	}
+	return h.authenticator.RequireScope(scope, handler)
 }
 
 func (h *HTTPHandlers) RootHandler(w http.ResponseWriter, r *http.Request) {
@@ -47,12 +124,53 @@ func (h *HTTPHandlers) RootHandler(w http.ResponseWriter, r *http.Request) {
 		"status":  "running",
 		"endpoints": map[string][]string{
 			"tasks": {
-				"POST /api/v1/tasks - Create task",
-				"GET /api/v1/tasks - List tasks (?completed=true/false)",
+				"POST /api/v1/tasks - Create task (supports Idempotency-Key header)",
+				"GET /api/v1/tasks - List tasks (?completed=true/false&limit=&cursor=&sort=created_at,-updated_at,title&q=&created_after=&updated_after=...&overdue=&due_before=&priority=high,urgent&tag=a&tag=b&tag_match=any|all&list_id=)",
+				"GET /api/v1/tasks/export - Export tasks matching the list filters (?format=csv|ndjson|md, streamed)",
+				"POST /api/v1/tasks:batch - Create, update and delete tasks in one request (atomic=true applies all or none)",
 				"GET /api/v1/tasks/{id} - Get task",
-				"PUT /api/v1/tasks/{id} - Update task",
-				"PATCH /api/v1/tasks/{id} - Partial update task",
-				"DELETE /api/v1/tasks/{id} - Delete task",
+				"PUT /api/v1/tasks/{id} - Replace task; all updatable fields are required, null clears (honors If-Match)",
+				"PATCH /api/v1/tasks/{id} - Partial update task; application/merge-patch+json lets null clear a field, application/json-patch+json applies RFC 6902 operations, including checklist changes under /items (honors If-Match)",
+				"DELETE /api/v1/tasks/{id} - Move task to the trash; permanent=true deletes it for good (honors If-Match)",
+				"POST /api/v1/tasks/{id}:restore - Restore task from the trash",
+				"GET /api/v1/tasks/{id}/history - List task revisions with actor, operation and per-field changes",
+			},
+			"checklist_items": {
+				"GET /api/v1/tasks/{id}/items - List checklist items with progress",
+				"POST /api/v1/tasks/{id}/items - Add checklist item",
+				"POST /api/v1/tasks/{id}/items:reorder - Reorder checklist items",
+				"GET /api/v1/tasks/{id}/items/{itemId} - Get checklist item",
+				"PUT /api/v1/tasks/{id}/items/{itemId} - Update checklist item",
+				"PATCH /api/v1/tasks/{id}/items/{itemId} - Partial update checklist item",
+				"DELETE /api/v1/tasks/{id}/items/{itemId} - Delete checklist item",
+			},
+			"shares": {
+				"GET /api/v1/tasks/{id}/shares - List task owner and shares",
+				"POST /api/v1/tasks/{id}/shares - Share task with a user as viewer, editor or owner",
+				"DELETE /api/v1/tasks/{id}/shares/{userId} - Revoke a user's share",
+			},
+			"lists": {
+				"GET /api/v1/lists - Get all lists (supports limit, cursor)",
+				"POST /api/v1/lists - Create list",
+				"GET /api/v1/lists/{id} - Get list by ID",
+				"PUT /api/v1/lists/{id} - Update list",
+				"PATCH /api/v1/lists/{id} - Partial update list",
+				"DELETE /api/v1/lists/{id} - Delete list (cascade=true also deletes its tasks)",
+				"GET /api/v1/lists/{id}/tasks - Get tasks in list (same filters as /tasks)",
+				"GET /api/v1/lists/{id}/shares - List list owner and shares",
+				"POST /api/v1/lists/{id}/shares - Share list with a user; list owners are editors of its tasks",
+				"DELETE /api/v1/lists/{id}/shares/{userId} - Revoke a user's list share",
+			},
+			"admin": {
+				"GET /api/v1/admin/api-keys - List API keys (scope: admin)",
+				"POST /api/v1/admin/api-keys - Create API key (scope: admin)",
+				"DELETE /api/v1/admin/api-keys/{id} - Revoke API key (scope: admin)",
+			},
+			"tags": {
+				"GET /api/v1/tags - List tags with usage counts",
+			},
+			"trash": {
+				"GET /api/v1/trash - List deleted tasks awaiting purge (same filters as GET /api/v1/tasks)",
 			},
 			"health": {
 				"GET /health - Health check",
@@ -119,4 +237,41 @@ func WriteErrorResponse(w http.ResponseWriter, message string, statusCode int) {
 		slog.Int("status_code", statusCode),
 		slog.String("message", message),
 	)
+}
+
+func writeServiceError(w http.ResponseWriter, err error, defaultMessage string) {
+	statusCode, message := serviceErrorStatus(err, defaultMessage)
+	WriteErrorResponse(w, message, statusCode)
+}
+
+const (
+	contentTypeJSON       = "application/json"
+	contentTypeMergePatch = "application/merge-patch+json"
+	contentTypeJSONPatch  = "application/json-patch+json"
+)
+
+// mediaType returns the request media type without parameters, or "" when
+// no Content-Type header was sent.
+func mediaType(r *http.Request) string {
+	value := r.Header.Get("Content-Type")
+	if value == "" {
+		return ""
+	}
+
+	parsed, _, err := mime.ParseMediaType(value)
+	if err != nil {
+		return value
+	}
+	return parsed
+}
+
+func serviceErrorStatus(err error, defaultMessage string) (int, string) {
+	statusCode := apiErrors.HTTPStatusFromError(err)
+	message := apiErrors.MessageFromError(err)
+
+	if message == "" || strings.Contains(message, "rpc") {
+		message = defaultMessage
+	}
+
+	return statusCode, message
 }
\ No newline at end of file
@@ -28,6 +28,12 @@ func (h *HealthHandlers) HandleHealthCheck(w http.ResponseWriter, r *http.Reques
 		Timestamp: health.Timestamp,
 	}
 
+	if health.OutboxDepth != nil {
+		healthStatus.Outbox = &dto.OutboxStatus{
+			QueueDepth: *health.OutboxDepth,
+		}
+	}
+
 	statusCode := h.getHTTPStatusCode(health.Status)
 	
 	WriteJSONResponse(w, statusCode, healthStatus)
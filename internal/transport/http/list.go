@@ -0,0 +1,201 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/service"
+	"github.com/Raisondetr3/checklist-api-service/internal/validator"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+
+	"github.com/gorilla/mux"
+)
+
+type ListHandlers struct {
+	listService service.ListService
+}
+
+func NewListHandlers(listService service.ListService) *ListHandlers {
+	return &ListHandlers{
+		listService: listService,
+	}
+}
+
+func (h *ListHandlers) HandleCreateList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req dto.CreateListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validator.ValidateCreateListRequest(req); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.listService.CreateList(ctx, req)
+	if err != nil {
+		writeServiceError(w, err, "Failed to create list")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusCreated, dto.ListModelToResponse(list))
+
+	slog.InfoContext(ctx, "List created via HTTP",
+		slog.String("list_id", list.ID),
+		slog.String("name", list.Name),
+	)
+}
+
+func (h *ListHandlers) HandleGetLists(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	var req dto.ListListsRequest
+	var err error
+
+	if req.Limit, err = validator.ValidateLimitParam(query.Get("limit")); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Cursor, err = validator.ValidateCursorParam(query.Get("cursor")); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.listService.GetLists(ctx, req)
+	if err != nil {
+		writeServiceError(w, err, "Failed to get lists")
+		return
+	}
+
+	setPaginationLinks(w, r, req.Limit, page.NextCursor)
+	WriteJSONResponse(w, http.StatusOK, dto.ListPageToResponse(page))
+
+	slog.InfoContext(ctx, "Lists retrieved via HTTP",
+		slog.Int("count", len(page.Lists)),
+		slog.Int("total_count", page.TotalCount),
+	)
+}
+
+func (h *ListHandlers) HandleGetList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	listID := mux.Vars(r)["id"]
+	if err := validator.ValidateListID(listID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.listService.GetList(ctx, listID)
+	if err != nil {
+		writeServiceError(w, err, "Failed to get list")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, dto.ListModelToResponse(list))
+}
+
+func (h *ListHandlers) HandleUpdateList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	listID := mux.Vars(r)["id"]
+	if err := validator.ValidateListID(listID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req dto.UpdateListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validator.ValidateUpdateListRequest(req); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.listService.UpdateList(ctx, listID, req)
+	if err != nil {
+		writeServiceError(w, err, "Failed to update list")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, dto.ListModelToResponse(list))
+
+	slog.InfoContext(ctx, "List updated via HTTP",
+		slog.String("list_id", listID),
+	)
+}
+
+func (h *ListHandlers) HandleDeleteList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	listID := mux.Vars(r)["id"]
+	if err := validator.ValidateListID(listID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cascade, err := validator.ValidateCascadeParam(r.URL.Query().Get("cascade"))
+	if err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	deletedTasks, err := h.listService.DeleteList(ctx, listID, cascade)
+	if err != nil {
+		writeServiceError(w, err, "Failed to delete list")
+		return
+	}
+
+	response := dto.DeleteListResponse{
+		Success:      true,
+		DeletedTasks: deletedTasks,
+	}
+	WriteJSONResponse(w, http.StatusOK, response)
+
+	slog.InfoContext(ctx, "List deleted via HTTP",
+		slog.String("list_id", listID),
+		slog.Bool("cascade", cascade),
+		slog.Int("deleted_tasks", deletedTasks),
+	)
+}
+
+func (h *ListHandlers) HandleGetListTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	listID := mux.Vars(r)["id"]
+	if err := validator.ValidateListID(listID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	listReq, err := parseListTasksQuery(r.URL.Query())
+	if err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.listService.GetListTasks(ctx, listID, listReq)
+	if err != nil {
+		writeServiceError(w, err, "Failed to get list tasks")
+		return
+	}
+
+	response := dto.TaskModelsToResponse(page.Tasks)
+	response.TotalCount = page.TotalCount
+	response.NextCursor = page.NextCursor
+
+	setPaginationLinks(w, r, listReq.Limit, page.NextCursor)
+	writeConditionalJSON(w, r, tasksETag(page.Tasks, page.TotalCount, page.NextCursor), response)
+
+	slog.InfoContext(ctx, "List tasks retrieved via HTTP",
+		slog.String("list_id", listID),
+		slog.Int("count", len(response.Tasks)),
+	)
+}
@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/auth"
+	"github.com/Raisondetr3/checklist-api-service/internal/config"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	Scope string `json:"scope,omitempty"`
+}
+
+// Authenticator accepts JWT bearer tokens and API keys. JWT support is
+// optional so deployments that only serve automation can run with API keys
+// alone, but at least one way to authenticate must exist: with no JWT keys
+// and no active API key every request would be rejected.
+type Authenticator struct {
+	keys    *auth.KeySet
+	parser  *jwt.Parser
+	apiKeys auth.APIKeyStore
+}
+
+func NewAuthenticator(cfg config.AuthConfig, apiKeys auth.APIKeyStore) (*Authenticator, error) {
+	keys, err := auth.LoadKeySet(cfg.JWT)
+	if err != nil && !errors.Is(err, auth.ErrNoKeysConfigured) {
+		return nil, err
+	}
+	if keys == nil && !auth.HasActiveKey(apiKeys) {
+		return nil, auth.ErrNoCredentials
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(cfg.JWT.Leeway),
+	}
+	if cfg.JWT.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.JWT.Issuer))
+	}
+	if cfg.JWT.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.JWT.Audience))
+	}
+
+	return &Authenticator{
+		keys:    keys,
+		parser:  jwt.NewParser(options...),
+		apiKeys: apiKeys,
+	}, nil
+}
+
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scheme, credentials, ok := authorizationHeader(r)
+		if !ok {
+			writeUnauthorized(w, "Missing or malformed Authorization header")
+			return
+		}
+
+		var principal auth.Principal
+		var err error
+
+		switch {
+		case strings.EqualFold(scheme, "Bearer") && a.keys != nil:
+			principal, err = a.verifyJWT(credentials)
+		case strings.EqualFold(scheme, "ApiKey") && a.apiKeys != nil:
+			principal, err = a.verifyAPIKey(credentials)
+		default:
+			writeUnauthorized(w, "Unsupported authorization scheme")
+			return
+		}
+
+		if err != nil {
+			slog.WarnContext(r.Context(), "Authentication failed",
+				slog.String("scheme", scheme),
+				slog.String("path", r.URL.Path),
+				slog.String("error", err.Error()),
+			)
+			writeUnauthorized(w, "Invalid or expired credentials")
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+	})
+}
+
+// RequireScope rejects requests whose principal lacks the given scope.
+func (a *Authenticator) RequireScope(scope string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := auth.PrincipalFromContext(r.Context())
+		if !ok {
+			writeUnauthorized(w, "Authentication required")
+			return
+		}
+
+		if !principal.HasScope(scope) {
+			slog.WarnContext(r.Context(), "Missing required scope",
+				slog.String("subject", principal.Subject),
+				slog.String("scope", scope),
+				slog.String("path", r.URL.Path),
+			)
+			writeJSONError(w, "Missing required scope: "+scope, http.StatusForbidden)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func (a *Authenticator) verifyJWT(tokenString string) (auth.Principal, error) {
+	var claims jwtClaims
+	if _, err := a.parser.ParseWithClaims(tokenString, &claims, a.keys.Keyfunc); err != nil {
+		return auth.Principal{}, err
+	}
+
+	if claims.Subject == "" {
+		return auth.Principal{}, jwt.ErrTokenRequiredClaimMissing
+	}
+
+	scopes := auth.DefaultScopes
+	if claims.Scope != "" {
+		scopes = strings.Fields(claims.Scope)
+	}
+
+	return auth.Principal{
+		Subject: claims.Subject,
+		Method:  auth.MethodJWT,
+		Scopes:  scopes,
+	}, nil
+}
+
+func (a *Authenticator) verifyAPIKey(secret string) (auth.Principal, error) {
+	key, err := a.apiKeys.Authenticate(secret)
+	if err != nil {
+		return auth.Principal{}, err
+	}
+
+	return key.Principal(), nil
+}
+
+func authorizationHeader(r *http.Request) (string, string, bool) {
+	scheme, credentials, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok {
+		return "", "", false
+	}
+
+	credentials = strings.TrimSpace(credentials)
+	return scheme, credentials, credentials != ""
+}
+
+func writeUnauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="checklist-api", ApiKey realm="checklist-api"`)
+	writeJSONError(w, message, http.StatusUnauthorized)
+}
+
+func writeJSONError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(dto.NewErr(message)); err != nil {
+		slog.Error("Failed to write error response",
+			slog.String("error", err.Error()),
+			slog.Int("status_code", statusCode),
+		)
+	}
+}
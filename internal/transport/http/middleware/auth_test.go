@@ -0,0 +1,284 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/auth"
+	"github.com/Raisondetr3/checklist-api-service/internal/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testHMACSecret = "test-hmac-secret"
+
+func newTestAuthenticator(t *testing.T, cfg config.JWTConfig, apiKeys auth.APIKeyStore) *Authenticator {
+	t.Helper()
+
+	if cfg.HMACSecret == "" && cfg.RSAPublicKeyFile == "" {
+		cfg.HMACSecret = testHMACSecret
+	}
+	a, err := NewAuthenticator(config.AuthConfig{Enabled: true, JWT: cfg}, apiKeys)
+	if err != nil {
+		t.Fatalf("NewAuthenticator: %v", err)
+	}
+	return a
+}
+
+func signHS256(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testHMACSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub": "alice",
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"iss": "https://issuer.test",
+		"aud": "checklist-api",
+	}
+}
+
+func withClaims(overrides jwt.MapClaims, remove ...string) jwt.MapClaims {
+	claims := validClaims()
+	for _, name := range remove {
+		delete(claims, name)
+	}
+	for name, value := range overrides {
+		claims[name] = value
+	}
+	return claims
+}
+
+func TestVerifyJWT(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(keyFile, publicPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.JWTConfig{
+		HMACSecret:       testHMACSecret,
+		RSAPublicKeyFile: keyFile,
+		Issuer:           "https://issuer.test",
+		Audience:         "checklist-api",
+		Leeway:           10 * time.Second,
+	}
+	a := newTestAuthenticator(t, cfg, nil)
+
+	sign := func(method jwt.SigningMethod, key any, claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		wantErr    error
+		wantFail   bool
+		wantScopes []string
+	}{
+		{name: "hs256", token: signHS256(t, validClaims()), wantScopes: auth.DefaultScopes},
+		{name: "rs256", token: sign(jwt.SigningMethodRS256, rsaKey, validClaims()), wantScopes: auth.DefaultScopes},
+		{
+			name:       "scope claim replaces the defaults",
+			token:      signHS256(t, withClaims(jwt.MapClaims{"scope": "tasks:read"})),
+			wantScopes: []string{auth.ScopeTasksRead},
+		},
+		{
+			name:       "scope claim can grant admin",
+			token:      signHS256(t, withClaims(jwt.MapClaims{"scope": "tasks:read admin"})),
+			wantScopes: []string{auth.ScopeTasksRead, auth.ScopeAdmin},
+		},
+		{name: "expired", token: signHS256(t, withClaims(jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()})), wantErr: jwt.ErrTokenExpired},
+		{name: "expiry within leeway", token: signHS256(t, withClaims(jwt.MapClaims{"exp": time.Now().Add(-5 * time.Second).Unix()})), wantScopes: auth.DefaultScopes},
+		{name: "missing exp", token: signHS256(t, withClaims(nil, "exp")), wantErr: jwt.ErrTokenRequiredClaimMissing},
+		{name: "not yet valid", token: signHS256(t, withClaims(jwt.MapClaims{"nbf": time.Now().Add(time.Hour).Unix()})), wantErr: jwt.ErrTokenNotValidYet},
+		{name: "wrong issuer", token: signHS256(t, withClaims(jwt.MapClaims{"iss": "https://evil.test"})), wantErr: jwt.ErrTokenInvalidIssuer},
+		{name: "missing issuer", token: signHS256(t, withClaims(nil, "iss")), wantErr: jwt.ErrTokenRequiredClaimMissing},
+		{name: "wrong audience", token: signHS256(t, withClaims(jwt.MapClaims{"aud": "other-api"})), wantErr: jwt.ErrTokenInvalidAudience},
+		{name: "audience list", token: signHS256(t, withClaims(jwt.MapClaims{"aud": []string{"other-api", "checklist-api"}})), wantScopes: auth.DefaultScopes},
+		{name: "missing subject", token: signHS256(t, withClaims(nil, "sub")), wantErr: jwt.ErrTokenRequiredClaimMissing},
+		{name: "wrong hmac secret", token: sign(jwt.SigningMethodHS256, []byte("other"), validClaims()), wantErr: jwt.ErrTokenSignatureInvalid},
+		{name: "disallowed algorithm", token: sign(jwt.SigningMethodHS512, []byte(testHMACSecret), validClaims()), wantErr: jwt.ErrTokenSignatureInvalid},
+		{name: "alg none", token: sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, validClaims()), wantErr: jwt.ErrTokenSignatureInvalid},
+		{
+			// An HS256 token keyed with the RSA public key must not be
+			// accepted as if the public key were a shared secret.
+			name:     "rsa public key used as hmac secret",
+			token:    sign(jwt.SigningMethodHS256, publicPEM, validClaims()),
+			wantFail: true,
+		},
+		{name: "garbage", token: "not.a.jwt", wantErr: jwt.ErrTokenMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := a.verifyJWT(tt.token)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			case tt.wantFail:
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			case err != nil:
+				t.Fatalf("verifyJWT: %v", err)
+			}
+
+			if principal.Subject != "alice" || principal.Method != auth.MethodJWT {
+				t.Errorf("principal = %+v, want subject alice via jwt", principal)
+			}
+			if !slices.Equal(principal.Scopes, tt.wantScopes) {
+				t.Errorf("scopes = %v, want %v", principal.Scopes, tt.wantScopes)
+			}
+		})
+	}
+}
+
+func TestNewAuthenticatorRequiresCredentials(t *testing.T) {
+	store, err := auth.NewFileAPIKeyStore(filepath.Join(t.TempDir(), "api_keys.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewAuthenticator(config.AuthConfig{Enabled: true}, store); !errors.Is(err, auth.ErrNoCredentials) {
+		t.Fatalf("error = %v, want %v", err, auth.ErrNoCredentials)
+	}
+
+	if _, _, err := store.Create("ci", auth.DefaultScopes); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewAuthenticator(config.AuthConfig{Enabled: true}, store); err != nil {
+		t.Fatalf("NewAuthenticator with an active key: %v", err)
+	}
+}
+
+func TestAuthenticatorMiddleware(t *testing.T) {
+	store, err := auth.NewFileAPIKeyStore(filepath.Join(t.TempDir(), "api_keys.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, secret, err := store.Create("ci", []string{auth.ScopeTasksRead})
+	if err != nil {
+		t.Fatal(err)
+	}
+	revoked, revokedSecret, err := store.Create("old", []string{auth.ScopeTasksRead})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Revoke(revoked.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	a := newTestAuthenticator(t, config.JWTConfig{}, store)
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantMethod    string
+	}{
+		{name: "missing header", wantStatus: http.StatusUnauthorized},
+		{name: "scheme without credentials", authorization: "Bearer ", wantStatus: http.StatusUnauthorized},
+		{name: "unsupported scheme", authorization: "Basic dXNlcjpwYXNz", wantStatus: http.StatusUnauthorized},
+		{name: "valid jwt", authorization: "Bearer " + signHS256(t, withClaims(nil, "iss", "aud")), wantStatus: http.StatusOK, wantMethod: auth.MethodJWT},
+		{name: "scheme is case-insensitive", authorization: "bearer " + signHS256(t, withClaims(nil, "iss", "aud")), wantStatus: http.StatusOK, wantMethod: auth.MethodJWT},
+		{name: "invalid jwt", authorization: "Bearer not.a.jwt", wantStatus: http.StatusUnauthorized},
+		{name: "valid api key", authorization: "ApiKey " + secret, wantStatus: http.StatusOK, wantMethod: auth.MethodAPIKey},
+		{name: "revoked api key", authorization: "ApiKey " + revokedSecret, wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got auth.Principal
+			handler := a.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, _ = auth.PrincipalFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("WWW-Authenticate header is missing")
+			}
+			if got.Method != tt.wantMethod {
+				t.Errorf("principal method = %q, want %q", got.Method, tt.wantMethod)
+			}
+		})
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	a := newTestAuthenticator(t, config.JWTConfig{}, nil)
+
+	tests := []struct {
+		name       string
+		principal  *auth.Principal
+		scope      string
+		wantStatus int
+	}{
+		{name: "no principal", scope: auth.ScopeTasksRead, wantStatus: http.StatusUnauthorized},
+		{name: "has scope", principal: &auth.Principal{Subject: "alice", Scopes: []string{auth.ScopeTasksRead}}, scope: auth.ScopeTasksRead, wantStatus: http.StatusOK},
+		{name: "missing scope", principal: &auth.Principal{Subject: "alice", Scopes: []string{auth.ScopeTasksRead}}, scope: auth.ScopeTasksWrite, wantStatus: http.StatusForbidden},
+		{name: "admin implies every scope", principal: &auth.Principal{Subject: "root", Scopes: []string{auth.ScopeAdmin}}, scope: auth.ScopeTasksDelete, wantStatus: http.StatusOK},
+		{name: "defaults exclude admin", principal: &auth.Principal{Subject: "alice", Scopes: auth.DefaultScopes}, scope: auth.ScopeAdmin, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := a.RequireScope(tt.scope, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks", nil)
+			if tt.principal != nil {
+				req = req.WithContext(auth.WithPrincipal(req.Context(), *tt.principal))
+			}
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/auth"
+	"github.com/Raisondetr3/checklist-api-service/internal/idempotency"
+)
+
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	maxIdempotencyKeyLength = 255
+	maxIdempotentBodySize   = 1 << 20
+)
+
+// captureWriter passes the response through while keeping a copy so it can
+// be replayed for retries.
+type captureWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (cw *captureWriter) WriteHeader(code int) {
+	cw.statusCode = code
+	cw.ResponseWriter.WriteHeader(code)
+}
+
+func (cw *captureWriter) Write(data []byte) (int, error) {
+	if cw.statusCode == 0 {
+		cw.statusCode = http.StatusOK
+	}
+	cw.body.Write(data)
+	return cw.ResponseWriter.Write(data)
+}
+
+// Idempotent replays the stored response for a repeated Idempotency-Key and
+// rejects a reused key whose request body differs. Requests without the
+// header are passed through unchanged.
+func Idempotent(store *idempotency.Store, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			next(w, r)
+			return
+		}
+		if !validIdempotencyKey(key) {
+			writeJSONError(w, "Idempotency-Key must be 1-255 printable ASCII characters", http.StatusBadRequest)
+			return
+		}
+
+		// Read one byte past the limit so an oversized body is rejected
+		// instead of being fingerprinted and processed truncated.
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxIdempotentBodySize+1))
+		if err != nil {
+			writeJSONError(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+		if len(body) > maxIdempotentBodySize {
+			writeJSONError(w, "Request body is too large for an idempotent request", http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		storeKey := auth.SubjectFromContext(r.Context()) + "\x00" + key
+		fingerprint := requestFingerprint(r, body)
+
+		stored, err := store.Begin(storeKey, fingerprint, time.Now())
+		switch {
+		case errors.Is(err, idempotency.ErrFingerprintMismatch):
+			writeJSONError(w, "Idempotency-Key was already used with a different request body", http.StatusUnprocessableEntity)
+			return
+		case errors.Is(err, idempotency.ErrInProgress):
+			writeJSONError(w, "A request with this Idempotency-Key is still being processed", http.StatusConflict)
+			return
+		case errors.Is(err, idempotency.ErrStoreFull):
+			w.Header().Set("Retry-After", "1")
+			writeJSONError(w, "Too many idempotent requests are in progress", http.StatusServiceUnavailable)
+			return
+		case stored != nil:
+			slog.InfoContext(r.Context(), "Replaying idempotent response",
+				slog.String("idempotency_key", key),
+				slog.Int("status_code", stored.StatusCode),
+			)
+			replay(w, stored)
+			return
+		}
+
+		completed := false
+		defer func() {
+			if !completed {
+				store.Release(storeKey)
+			}
+		}()
+
+		cw := &captureWriter{ResponseWriter: w}
+		next(cw, r.WithContext(idempotency.WithKey(r.Context(), key)))
+
+		// Server errors are not cached so the client can retry them.
+		if cw.statusCode >= http.StatusInternalServerError || cw.statusCode == 0 {
+			return
+		}
+
+		store.Complete(storeKey, &idempotency.Response{
+			StatusCode: cw.statusCode,
+			Header:     cw.Header().Clone(),
+			Body:       bytes.Clone(cw.body.Bytes()),
+		}, time.Now())
+		completed = true
+	}
+}
+
+func validIdempotencyKey(key string) bool {
+	if len(key) > maxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func replay(w http.ResponseWriter, response *idempotency.Response) {
+	for name, values := range response.Header {
+		if name == "X-Request-Id" {
+			continue
+		}
+		w.Header()[name] = values
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(response.StatusCode)
+
+	if _, err := w.Write(response.Body); err != nil {
+		slog.Error("Failed to write replayed response", slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/idempotency"
+)
+
+type idempotentCall struct {
+	subject string
+	key     string
+	body    string
+}
+
+func TestIdempotent(t *testing.T) {
+	tests := []struct {
+		name         string
+		calls        []idempotentCall
+		wantStatus   int
+		wantReplayed bool
+		wantHandled  int
+	}{
+		{
+			name:        "no key",
+			calls:       []idempotentCall{{body: `{}`}, {body: `{}`}},
+			wantStatus:  http.StatusCreated,
+			wantHandled: 2,
+		},
+		{
+			name:         "retry is replayed",
+			calls:        []idempotentCall{{key: "k1", body: `{"title":"a"}`}, {key: "k1", body: `{"title":"a"}`}},
+			wantStatus:   http.StatusCreated,
+			wantReplayed: true,
+			wantHandled:  1,
+		},
+		{
+			name:        "reused key with a different body",
+			calls:       []idempotentCall{{key: "k1", body: `{"title":"a"}`}, {key: "k1", body: `{"title":"b"}`}},
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantHandled: 1,
+		},
+		{
+			name: "keys are scoped to the subject",
+			calls: []idempotentCall{
+				{subject: "alice", key: "k1", body: `{"title":"a"}`},
+				{subject: "bob", key: "k1", body: `{"title":"b"}`},
+			},
+			wantStatus:  http.StatusCreated,
+			wantHandled: 2,
+		},
+		{
+			name: "same subject shares keys",
+			calls: []idempotentCall{
+				{subject: "alice", key: "k1", body: `{"title":"a"}`},
+				{subject: "alice", key: "k1", body: `{"title":"a"}`},
+			},
+			wantStatus:   http.StatusCreated,
+			wantReplayed: true,
+			wantHandled:  1,
+		},
+		{
+			name:       "invalid key",
+			calls:      []idempotentCall{{key: "bad\nkey", body: `{}`}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "body over the limit",
+			calls:      []idempotentCall{{key: "k1", body: strings.Repeat("a", maxIdempotentBodySize+1)}},
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:        "body at the limit",
+			calls:       []idempotentCall{{key: "k1", body: strings.Repeat("a", maxIdempotentBodySize)}},
+			wantStatus:  http.StatusCreated,
+			wantHandled: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := idempotency.NewStore(time.Hour, 100)
+			handled := 0
+			handler := Idempotent(store, func(w http.ResponseWriter, r *http.Request) {
+				handled++
+				body, _ := io.ReadAll(r.Body)
+				w.WriteHeader(http.StatusCreated)
+				w.Write(body[:min(len(body), 32)])
+			})
+
+			var rec *httptest.ResponseRecorder
+			for _, call := range tt.calls {
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", strings.NewReader(call.body))
+				if call.key != "" {
+					req.Header.Set(IdempotencyKeyHeader, call.key)
+				}
+				if call.subject != "" {
+					req = withPrincipal(req, call.subject)
+				}
+
+				rec = httptest.NewRecorder()
+				handler(rec, req)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if replayed := rec.Header().Get("Idempotent-Replayed") == "true"; replayed != tt.wantReplayed {
+				t.Errorf("replayed = %v, want %v", replayed, tt.wantReplayed)
+			}
+			if handled != tt.wantHandled {
+				t.Errorf("handler ran %d times, want %d", handled, tt.wantHandled)
+			}
+		})
+	}
+}
+
+func TestIdempotentServerErrorIsNotStored(t *testing.T) {
+	store := idempotency.NewStore(time.Hour, 100)
+	handled := 0
+	handler := Idempotent(store, func(w http.ResponseWriter, r *http.Request) {
+		handled++
+		if handled == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	for range 2 {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", strings.NewReader(`{}`))
+		req.Header.Set(IdempotencyKeyHeader, "k1")
+		handler(httptest.NewRecorder(), req)
+	}
+
+	if handled != 2 {
+		t.Errorf("handler ran %d times, want 2", handled)
+	}
+}
+
+func TestValidIdempotencyKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"8e3f0c1a-4b2d-4f6e-9a7b-1c2d3e4f5a6b", true},
+		{"order 42", true},
+		{strings.Repeat("k", maxIdempotencyKeyLength), true},
+		{strings.Repeat("k", maxIdempotencyKeyLength+1), false},
+		{"tab\tkey", false},
+		{"ключ", false},
+	}
+
+	for _, tt := range tests {
+		if got := validIdempotencyKey(tt.key); got != tt.want {
+			t.Errorf("validIdempotencyKey(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
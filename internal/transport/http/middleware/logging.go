@@ -30,13 +30,19 @@ func (rw *responseWriter) Write(data []byte) (int, error) {
 	return n, err
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to
+// flush streamed responses.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 func LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
 		requestID := uuid.New().String()
 
-		ctx := r.Context()
+		ctx := logger.WithRequestID(r.Context(), requestID)
 		r = r.WithContext(ctx)
 
 		w.Header().Set("X-Request-ID", requestID)
@@ -73,6 +79,12 @@ func PanicRecoveryMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
+				// ErrAbortHandler asks the server to drop the connection
+				// mid-response; let it through.
+				if err == http.ErrAbortHandler {
+					panic(err)
+				}
+
 				slog.Error("Panic recovered",
 					slog.Any("panic", err),
 					slog.String("method", r.Method),
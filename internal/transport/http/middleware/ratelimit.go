@@ -0,0 +1,188 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/auth"
+	"github.com/Raisondetr3/checklist-api-service/internal/config"
+)
+
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+type tokenBuckets struct {
+	rule config.RateLimitRule
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type rateDecision struct {
+	allowed    bool
+	remaining  int
+	reset      time.Duration
+	retryAfter time.Duration
+}
+
+func (tb *tokenBuckets) take(key string, now time.Time) rateDecision {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	burst := float64(tb.rule.Burst)
+	b, ok := tb.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, lastSeen: now}
+		tb.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.lastSeen).Seconds()
+	b.tokens = math.Min(burst, b.tokens+elapsed*tb.rule.RequestsPerSecond)
+	b.lastSeen = now
+
+	decision := rateDecision{allowed: b.tokens >= 1}
+	if decision.allowed {
+		b.tokens--
+	} else {
+		decision.retryAfter = tb.secondsFor(1 - b.tokens)
+	}
+
+	decision.remaining = int(b.tokens)
+	decision.reset = tb.secondsFor(burst - b.tokens)
+
+	return decision
+}
+
+func (tb *tokenBuckets) secondsFor(tokens float64) time.Duration {
+	return time.Duration(tokens / tb.rule.RequestsPerSecond * float64(time.Second))
+}
+
+// evict drops buckets that have been idle for longer than ttl; a refilled
+// bucket is indistinguishable from a new one.
+func (tb *tokenBuckets) evict(now time.Time, ttl time.Duration) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	for key, b := range tb.buckets {
+		if now.Sub(b.lastSeen) > ttl {
+			delete(tb.buckets, key)
+		}
+	}
+}
+
+// RateLimiter applies per-client token buckets, with separate limits for
+// reads and writes. Clients are identified by API key, then authenticated
+// user, then remote IP.
+type RateLimiter struct {
+	read    *tokenBuckets
+	write   *tokenBuckets
+	key     func(r *http.Request) string
+	idleTTL time.Duration
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+func NewRateLimiter(cfg config.RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		read:      &tokenBuckets{rule: cfg.Read, buckets: make(map[string]*bucket)},
+		write:     &tokenBuckets{rule: cfg.Write, buckets: make(map[string]*bucket)},
+		key:       clientKey,
+		idleTTL:   cfg.IdleTTL,
+		lastSweep: time.Now(),
+	}
+}
+
+// NewIPRateLimiter limits every request by remote IP with the IP rule, using
+// one bucket for reads and writes. It runs before authentication so requests
+// with missing or invalid credentials are throttled too.
+func NewIPRateLimiter(cfg config.RateLimitConfig) *RateLimiter {
+	buckets := &tokenBuckets{rule: cfg.IP, buckets: make(map[string]*bucket)}
+	return &RateLimiter{
+		read:      buckets,
+		write:     buckets,
+		key:       ipKey,
+		idleTTL:   cfg.IdleTTL,
+		lastSweep: time.Now(),
+	}
+}
+
+func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		now := time.Now()
+		rl.sweep(now)
+
+		buckets := rl.write
+		if isReadMethod(r.Method) {
+			buckets = rl.read
+		}
+
+		key := rl.key(r)
+		decision := buckets.take(key, now)
+
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(buckets.rule.Burst))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(decision.remaining))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.reset)))
+
+		if !decision.allowed {
+			slog.WarnContext(r.Context(), "Rate limit exceeded",
+				slog.String("client", key),
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+			)
+
+			w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(decision.retryAfter)))
+			writeJSONError(w, "Rate limit exceeded, retry later", http.StatusTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (rl *RateLimiter) sweep(now time.Time) {
+	rl.mu.Lock()
+	if now.Sub(rl.lastSweep) < rl.idleTTL {
+		rl.mu.Unlock()
+		return
+	}
+	rl.lastSweep = now
+	rl.mu.Unlock()
+
+	rl.read.evict(now, rl.idleTTL)
+	rl.write.evict(now, rl.idleTTL)
+}
+
+func clientKey(r *http.Request) string {
+	if principal, ok := auth.PrincipalFromContext(r.Context()); ok && principal.Subject != "" {
+		if principal.Method == auth.MethodAPIKey {
+			return principal.Subject
+		}
+		return "user:" + principal.Subject
+	}
+
+	return ipKey(r)
+}
+
+func ipKey(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,247 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/auth"
+	"github.com/Raisondetr3/checklist-api-service/internal/config"
+)
+
+func TestTokenBucketsTake(t *testing.T) {
+	rule := config.RateLimitRule{RequestsPerSecond: 2, Burst: 3}
+	start := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name          string
+		takes         []time.Duration
+		wantAllowed   bool
+		wantRemaining int
+		wantRetry     time.Duration
+	}{
+		{
+			name:          "fresh bucket starts full",
+			takes:         []time.Duration{0},
+			wantAllowed:   true,
+			wantRemaining: 2,
+		},
+		{
+			name:          "burst exhausted",
+			takes:         []time.Duration{0, 0, 0, 0},
+			wantAllowed:   false,
+			wantRemaining: 0,
+			wantRetry:     500 * time.Millisecond,
+		},
+		{
+			name:          "partial refill",
+			takes:         []time.Duration{0, 0, 0, 250 * time.Millisecond},
+			wantAllowed:   false,
+			wantRemaining: 0,
+			wantRetry:     250 * time.Millisecond,
+		},
+		{
+			name:          "refill allows one more",
+			takes:         []time.Duration{0, 0, 0, 500 * time.Millisecond},
+			wantAllowed:   true,
+			wantRemaining: 0,
+		},
+		{
+			name:          "refill is capped at burst",
+			takes:         []time.Duration{0, time.Hour},
+			wantAllowed:   true,
+			wantRemaining: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &tokenBuckets{rule: rule, buckets: make(map[string]*bucket)}
+
+			var decision rateDecision
+			for _, offset := range tt.takes {
+				decision = tb.take("client", start.Add(offset))
+			}
+
+			if decision.allowed != tt.wantAllowed {
+				t.Errorf("allowed = %v, want %v", decision.allowed, tt.wantAllowed)
+			}
+			if decision.remaining != tt.wantRemaining {
+				t.Errorf("remaining = %d, want %d", decision.remaining, tt.wantRemaining)
+			}
+			if decision.retryAfter != tt.wantRetry {
+				t.Errorf("retryAfter = %v, want %v", decision.retryAfter, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func TestTokenBucketsEvict(t *testing.T) {
+	rule := config.RateLimitRule{RequestsPerSecond: 1, Burst: 1}
+	tb := &tokenBuckets{rule: rule, buckets: make(map[string]*bucket)}
+	start := time.Unix(1700000000, 0)
+
+	tb.take("idle", start)
+	tb.take("active", start.Add(50*time.Second))
+	tb.evict(start.Add(70*time.Second), time.Minute)
+
+	if _, ok := tb.buckets["idle"]; ok {
+		t.Error("idle bucket was not evicted")
+	}
+	if _, ok := tb.buckets["active"]; !ok {
+		t.Error("active bucket was evicted")
+	}
+}
+
+func TestRateLimiterMiddleware(t *testing.T) {
+	cfg := config.RateLimitConfig{
+		Enabled: true,
+		Read:    config.RateLimitRule{RequestsPerSecond: 0.001, Burst: 2},
+		Write:   config.RateLimitRule{RequestsPerSecond: 0.001, Burst: 1},
+		IdleTTL: time.Hour,
+	}
+
+	tests := []struct {
+		name       string
+		requests   []*http.Request
+		wantStatus int
+		wantLimit  string
+	}{
+		{
+			name:       "reads within burst",
+			requests:   []*http.Request{newRequest("GET", "10.0.0.1:1000"), newRequest("GET", "10.0.0.1:1001")},
+			wantStatus: http.StatusOK,
+			wantLimit:  "2",
+		},
+		{
+			name:       "reads over burst",
+			requests:   []*http.Request{newRequest("GET", "10.0.0.1:1000"), newRequest("GET", "10.0.0.1:1000"), newRequest("GET", "10.0.0.1:1000")},
+			wantStatus: http.StatusTooManyRequests,
+			wantLimit:  "2",
+		},
+		{
+			name:       "writes have their own bucket",
+			requests:   []*http.Request{newRequest("GET", "10.0.0.1:1000"), newRequest("GET", "10.0.0.1:1000"), newRequest("POST", "10.0.0.1:1000")},
+			wantStatus: http.StatusOK,
+			wantLimit:  "1",
+		},
+		{
+			name:       "writes over burst",
+			requests:   []*http.Request{newRequest("POST", "10.0.0.1:1000"), newRequest("DELETE", "10.0.0.1:1000")},
+			wantStatus: http.StatusTooManyRequests,
+			wantLimit:  "1",
+		},
+		{
+			name:       "clients are limited separately",
+			requests:   []*http.Request{newRequest("POST", "10.0.0.1:1000"), newRequest("POST", "10.0.0.2:1000")},
+			wantStatus: http.StatusOK,
+			wantLimit:  "1",
+		},
+		{
+			name: "principal is limited across addresses",
+			requests: []*http.Request{
+				withPrincipal(newRequest("POST", "10.0.0.1:1000"), "alice"),
+				withPrincipal(newRequest("POST", "10.0.0.2:1000"), "alice"),
+			},
+			wantStatus: http.StatusTooManyRequests,
+			wantLimit:  "1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewRateLimiter(cfg)
+			handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			var rec *httptest.ResponseRecorder
+			for _, req := range tt.requests {
+				rec = httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("RateLimit-Limit"); got != tt.wantLimit {
+				t.Errorf("RateLimit-Limit = %q, want %q", got, tt.wantLimit)
+			}
+			if tt.wantStatus == http.StatusTooManyRequests && rec.Header().Get("Retry-After") == "" {
+				t.Error("Retry-After header is missing")
+			}
+		})
+	}
+}
+
+func TestIPRateLimiterIgnoresPrincipal(t *testing.T) {
+	cfg := config.RateLimitConfig{
+		Enabled: true,
+		IP:      config.RateLimitRule{RequestsPerSecond: 0.001, Burst: 1},
+		IdleTTL: time.Hour,
+	}
+
+	limiter := NewIPRateLimiter(cfg)
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Unauthenticated and authenticated requests from one address share a
+	// bucket, so rotating credentials does not reset the limit.
+	requests := []*http.Request{
+		newRequest("GET", "10.0.0.1:1000"),
+		withPrincipal(newRequest("POST", "10.0.0.1:1001"), "alice"),
+	}
+
+	var rec *httptest.ResponseRecorder
+	for _, req := range requests {
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+	}
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+}
+
+func TestClientKey(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *http.Request
+		want string
+	}{
+		{"remote address", newRequest("GET", "192.0.2.1:4321"), "ip:192.0.2.1"},
+		{"remote address without port", newRequest("GET", "192.0.2.1"), "ip:192.0.2.1"},
+		{"jwt user", withPrincipal(newRequest("GET", "192.0.2.1:4321"), "alice"), "user:alice"},
+		{
+			"api key",
+			newRequestWithPrincipal(auth.Principal{Subject: "key:abc", Method: auth.MethodAPIKey}),
+			"key:abc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clientKey(tt.req); got != tt.want {
+				t.Errorf("clientKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func newRequest(method, remoteAddr string) *http.Request {
+	req := httptest.NewRequest(method, "/api/v1/tasks", nil)
+	req.RemoteAddr = remoteAddr
+	return req
+}
+
+func withPrincipal(req *http.Request, subject string) *http.Request {
+	principal := auth.Principal{Subject: subject, Method: auth.MethodJWT, Scopes: auth.DefaultScopes}
+	return req.WithContext(auth.WithPrincipal(req.Context(), principal))
+}
+
+func newRequestWithPrincipal(principal auth.Principal) *http.Request {
+	req := newRequest("GET", "192.0.2.1:4321")
+	return req.WithContext(auth.WithPrincipal(req.Context(), principal))
+}
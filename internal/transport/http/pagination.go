@@ -0,0 +1,37 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+func setPaginationLinks(w http.ResponseWriter, r *http.Request, limit int, nextCursor string) {
+	links := []string{
+		formatLink(r.URL, limit, "", "first"),
+	}
+
+	if nextCursor != "" {
+		links = append(links, formatLink(r.URL, limit, nextCursor, "next"))
+	}
+
+	w.Header().Set("Link", strings.Join(links, ", "))
+}
+
+func formatLink(base *url.URL, limit int, cursor string, rel string) string {
+	query := base.Query()
+	query.Set("limit", strconv.Itoa(limit))
+	query.Del("cursor")
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	target := url.URL{
+		Path:     base.Path,
+		RawQuery: query.Encode(),
+	}
+
+	return fmt.Sprintf("<%s>; rel=\"%s\"", target.String(), rel)
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetPaginationLinks(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		limit      int
+		nextCursor string
+		want       string
+	}{
+		{
+			name:   "last page",
+			target: "/api/v1/tasks?completed=true",
+			limit:  50,
+			want:   `</api/v1/tasks?completed=true&limit=50>; rel="first"`,
+		},
+		{
+			name:       "next page keeps the other parameters",
+			target:     "/api/v1/tasks?completed=false&sort=-due_at&tag=home&tag=work&limit=20",
+			limit:      20,
+			nextCursor: "abc",
+			want: `</api/v1/tasks?completed=false&limit=20&sort=-due_at&tag=home&tag=work>; rel="first", ` +
+				`</api/v1/tasks?completed=false&cursor=abc&limit=20&sort=-due_at&tag=home&tag=work>; rel="next"`,
+		},
+		{
+			name:       "current cursor is replaced",
+			target:     "/api/v1/tasks?cursor=old&q=milk",
+			limit:      50,
+			nextCursor: "new",
+			want: `</api/v1/tasks?limit=50&q=milk>; rel="first", ` +
+				`</api/v1/tasks?cursor=new&limit=50&q=milk>; rel="next"`,
+		},
+		{
+			name:       "cursor is escaped",
+			target:     "/api/v1/lists/groceries/tasks",
+			limit:      10,
+			nextCursor: "a+b/c=",
+			want: `</api/v1/lists/groceries/tasks?limit=10>; rel="first", ` +
+				`</api/v1/lists/groceries/tasks?cursor=a%2Bb%2Fc%3D&limit=10>; rel="next"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			rec := httptest.NewRecorder()
+
+			setPaginationLinks(rec, req, tt.limit, tt.nextCursor)
+
+			if got := rec.Header().Get("Link"); got != tt.want {
+				t.Errorf("Link = %s\nwant   %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/service"
+	"github.com/Raisondetr3/checklist-api-service/internal/validator"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+
+	"github.com/gorilla/mux"
+)
+
+type ShareHandlers struct {
+	shareService service.ShareService
+}
+
+func NewShareHandlers(shareService service.ShareService) *ShareHandlers {
+	return &ShareHandlers{
+		shareService: shareService,
+	}
+}
+
+func (h *ShareHandlers) HandleListShares(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := mux.Vars(r)["id"]
+	if err := validator.ValidateTaskID(taskID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ownerID, shares, err := h.shareService.ListShares(ctx, taskID)
+	if err != nil {
+		writeServiceError(w, err, "Failed to get task shares")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, dto.SharesToListResponse(ownerID, shares))
+}
+
+func (h *ShareHandlers) HandleShareTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := mux.Vars(r)["id"]
+	if err := validator.ValidateTaskID(taskID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req dto.ShareTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validator.ValidateShareTaskRequest(req); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	share, err := h.shareService.ShareTask(ctx, taskID, req)
+	if err != nil {
+		writeServiceError(w, err, "Failed to share task")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, dto.ShareToResponse(*share))
+
+	slog.InfoContext(ctx, "Task shared via HTTP",
+		slog.String("task_id", taskID),
+		slog.String("user_id", share.UserID),
+		slog.String("role", string(share.Role)),
+	)
+}
+
+func (h *ShareHandlers) HandleUnshareTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+	userID := vars["userId"]
+
+	if err := validator.ValidateTaskID(taskID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validator.ValidateShareUserID(userID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.shareService.UnshareTask(ctx, taskID, userID); err != nil {
+		writeServiceError(w, err, "Failed to unshare task")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, dto.DeleteTaskResponse{Success: true})
+
+	slog.InfoContext(ctx, "Task unshared via HTTP",
+		slog.String("task_id", taskID),
+		slog.String("user_id", userID),
+	)
+}
+
+func (h *ShareHandlers) HandleListListShares(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	listID := mux.Vars(r)["id"]
+	if err := validator.ValidateListID(listID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ownerID, shares, err := h.shareService.ListListShares(ctx, listID)
+	if err != nil {
+		writeServiceError(w, err, "Failed to get list shares")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, dto.SharesToListResponse(ownerID, shares))
+}
+
+func (h *ShareHandlers) HandleShareList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	listID := mux.Vars(r)["id"]
+	if err := validator.ValidateListID(listID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req dto.ShareTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validator.ValidateShareTaskRequest(req); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	share, err := h.shareService.ShareList(ctx, listID, req)
+	if err != nil {
+		writeServiceError(w, err, "Failed to share list")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, dto.ShareToResponse(*share))
+
+	slog.InfoContext(ctx, "List shared via HTTP",
+		slog.String("list_id", listID),
+		slog.String("user_id", share.UserID),
+		slog.String("role", string(share.Role)),
+	)
+}
+
+func (h *ShareHandlers) HandleUnshareList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	listID := vars["id"]
+	userID := vars["userId"]
+
+	if err := validator.ValidateListID(listID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validator.ValidateShareUserID(userID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.shareService.UnshareList(ctx, listID, userID); err != nil {
+		writeServiceError(w, err, "Failed to unshare list")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, dto.DeleteTaskResponse{Success: true})
+
+	slog.InfoContext(ctx, "List unshared via HTTP",
+		slog.String("list_id", listID),
+		slog.String("user_id", userID),
+	)
+}
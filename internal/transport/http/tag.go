@@ -0,0 +1,26 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+)
+
+func (h *TaskHandlers) HandleGetTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tags, err := h.taskService.GetTagCounts(ctx)
+	if err != nil {
+		h.handleServiceError(w, err, "Failed to get tags")
+		return
+	}
+
+	response := dto.TagCountsToResponse(tags)
+
+	WriteJSONResponse(w, http.StatusOK, response)
+
+	slog.InfoContext(ctx, "Tags retrieved via HTTP",
+		slog.Int("count", len(tags)),
+	)
+}
@@ -2,14 +2,16 @@ package http
 
 import (
 	"encoding/json"
+	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"strings"
 
+	"github.com/Raisondetr3/checklist-api-service/internal/jsonpatch"
 	"github.com/Raisondetr3/checklist-api-service/internal/service"
 	"github.com/Raisondetr3/checklist-api-service/internal/validator"
 	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
-	apiErrors "github.com/Raisondetr3/checklist-api-service/pkg/errors"
 
 	"github.com/gorilla/mux"
 )
@@ -48,6 +50,7 @@ func (h *TaskHandlers) HandleCreateTask(w http.ResponseWriter, r *http.Request)
 
 	response := dto.TaskModelToResponse(createdTask)
 
+	setTaskETag(w, createdTask)
 	WriteJSONResponse(w, http.StatusCreated, response)
 	
 	slog.InfoContext(ctx, "Task created via HTTP",
@@ -59,31 +62,97 @@ func (h *TaskHandlers) HandleCreateTask(w http.ResponseWriter, r *http.Request)
 func (h *TaskHandlers) HandleGetTasks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	completed, err := validator.ValidateCompletedParam(r.URL.Query().Get("completed"))
+	listReq, err := parseListTasksQuery(r.URL.Query())
 	if err != nil {
 		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	tasks, totalCount, err := h.taskService.GetTasks(ctx, completed)
+	page, err := h.taskService.GetTasks(ctx, listReq)
 	if err != nil {
 		h.handleServiceError(w, err, "Failed to get tasks")
 		return
 	}
 
-	response := dto.TaskModelsToResponse(tasks)
+	response := dto.TaskModelsToResponse(page.Tasks)
+	response.TotalCount = page.TotalCount
+	response.NextCursor = page.NextCursor
 
-	WriteJSONResponse(w, http.StatusOK, map[string]interface{}{
-		"tasks":       response.Tasks,
-		"total_count": totalCount,
-	})
+	setPaginationLinks(w, r, listReq.Limit, page.NextCursor)
+	writeConditionalJSON(w, r, tasksETag(page.Tasks, page.TotalCount, page.NextCursor), response)
 
 	slog.InfoContext(ctx, "Tasks retrieved via HTTP",
-		slog.Int("count", len(tasks)),
-		slog.Int("total_count", totalCount),
+		slog.Int("count", len(page.Tasks)),
+		slog.Int("total_count", page.TotalCount),
+		slog.Bool("has_next_page", page.NextCursor != ""),
 	)
 }
 
+func parseListTasksQuery(query url.Values) (dto.ListTasksRequest, error) {
+	var listReq dto.ListTasksRequest
+	var err error
+
+	if listReq.Completed, err = validator.ValidateCompletedParam(query.Get("completed")); err != nil {
+		return listReq, err
+	}
+	if listReq.Limit, err = validator.ValidateLimitParam(query.Get("limit")); err != nil {
+		return listReq, err
+	}
+	if listReq.Cursor, err = validator.ValidateCursorParam(query.Get("cursor")); err != nil {
+		return listReq, err
+	}
+	if listReq.Sort, err = validator.ValidateSortParam(query.Get("sort")); err != nil {
+		return listReq, err
+	}
+	if listReq.Query, err = validator.ValidateSearchParam(query.Get("q")); err != nil {
+		return listReq, err
+	}
+
+	if listReq.CreatedAfter, err = validator.ValidateTimestampParam("created_after", query.Get("created_after")); err != nil {
+		return listReq, err
+	}
+	if listReq.CreatedBefore, err = validator.ValidateTimestampParam("created_before", query.Get("created_before")); err != nil {
+		return listReq, err
+	}
+	if listReq.UpdatedAfter, err = validator.ValidateTimestampParam("updated_after", query.Get("updated_after")); err != nil {
+		return listReq, err
+	}
+	if listReq.UpdatedBefore, err = validator.ValidateTimestampParam("updated_before", query.Get("updated_before")); err != nil {
+		return listReq, err
+	}
+	if err = validator.ValidateTimeRange(listReq.CreatedAfter, listReq.CreatedBefore); err != nil {
+		return listReq, err
+	}
+	if err = validator.ValidateTimeRange(listReq.UpdatedAfter, listReq.UpdatedBefore); err != nil {
+		return listReq, err
+	}
+
+	if listReq.Overdue, err = validator.ValidateOverdueParam(query.Get("overdue")); err != nil {
+		return listReq, err
+	}
+	if listReq.DueBefore, err = validator.ValidateTimestampParam("due_before", query.Get("due_before")); err != nil {
+		return listReq, err
+	}
+	if listReq.Priorities, err = validator.ValidatePriorityParam(query.Get("priority")); err != nil {
+		return listReq, err
+	}
+	if listReq.Tags, err = validator.ValidateTagsParam(query["tag"]); err != nil {
+		return listReq, err
+	}
+	if listReq.MatchAllTags, err = validator.ValidateTagMatchParam(query.Get("tag_match")); err != nil {
+		return listReq, err
+	}
+
+	if listID := query.Get("list_id"); listID != "" {
+		if err = validator.ValidateListID(listID); err != nil {
+			return listReq, err
+		}
+		listReq.ListID = listID
+	}
+
+	return listReq, nil
+}
+
 func (h *TaskHandlers) HandleGetTask(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -103,18 +172,43 @@ func (h *TaskHandlers) HandleGetTask(w http.ResponseWriter, r *http.Request) {
 
 	response := dto.TaskModelToResponse(task)
 
-	WriteJSONResponse(w, http.StatusOK, response)
+	writeConditionalJSON(w, r, taskETag(task), response)
 
 	slog.InfoContext(ctx, "Task retrieved via HTTP",
 		slog.String("task_id", response.ID),
 	)
 }
 
-func (h *TaskHandlers) HandleUpdateTask(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// HandleReplaceTask serves PUT: the body is the full task representation and
+// every updatable field is written.
+func (h *TaskHandlers) HandleReplaceTask(w http.ResponseWriter, r *http.Request) {
+	taskID := mux.Vars(r)["id"]
 
-	vars := mux.Vars(r)
-	taskID := vars["id"]
+	if err := validator.ValidateTaskID(taskID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		WriteErrorResponse(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	req, err := validator.ValidateReplaceTaskRequest(body)
+	if err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.updateTask(w, r, taskID, req)
+}
+
+// HandleUpdateTask serves PATCH. application/json-patch+json follows RFC 6902,
+// application/merge-patch+json follows RFC 7396 so null clears a field, and
+// plain application/json only writes the non-null fields it contains.
+func (h *TaskHandlers) HandleUpdateTask(w http.ResponseWriter, r *http.Request) {
+	taskID := mux.Vars(r)["id"]
 
 	if err := validator.ValidateTaskID(taskID); err != nil {
 		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
@@ -122,17 +216,92 @@ func (h *TaskHandlers) HandleUpdateTask(w http.ResponseWriter, r *http.Request)
 	}
 
 	var req dto.UpdateTaskRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		WriteErrorResponse(w, "Invalid JSON format", http.StatusBadRequest)
+
+	switch mediaType(r) {
+	case "", contentTypeJSON:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteErrorResponse(w, "Invalid JSON format", http.StatusBadRequest)
+			return
+		}
+
+		if err := validator.ValidateUpdateTaskRequest(req); err != nil {
+			WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+	case contentTypeMergePatch:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			WriteErrorResponse(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+
+		if req, err = validator.ValidateMergePatchTaskRequest(body); err != nil {
+			WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+	case contentTypeJSONPatch:
+		h.patchTask(w, r, taskID)
+		return
+
+	default:
+		w.Header().Set("Accept-Patch", strings.Join([]string{contentTypeJSONPatch, contentTypeMergePatch, contentTypeJSON}, ", "))
+		WriteErrorResponse(w, "Unsupported Content-Type. Use application/json-patch+json, application/merge-patch+json or application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	h.updateTask(w, r, taskID, req)
+}
+
+// patchTask applies an RFC 6902 JSON Patch. A failing test operation
+// responds with 409 Conflict and leaves the task unchanged.
+func (h *TaskHandlers) patchTask(w http.ResponseWriter, r *http.Request, taskID string) {
+	ctx := r.Context()
+
+	var patch []jsonpatch.Operation
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		WriteErrorResponse(w, "Invalid JSON Patch format. Expected an array of operations", http.StatusBadRequest)
 		return
 	}
 
-	if err := validator.ValidateUpdateTaskRequest(req); err != nil {
+	if err := validator.ValidateJSONPatch(patch); err != nil {
 		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	updatedTask, err := h.taskService.UpdateTask(ctx, taskID, req.Title, req.Description, req.Completed)
+	expectedVersion, ok := h.parseIfMatch(w, r, taskID, false)
+	if !ok {
+		return
+	}
+
+	patchedTask, err := h.taskService.PatchTask(ctx, taskID, patch, expectedVersion)
+	if err != nil {
+		h.handleServiceError(w, err, "Failed to patch task")
+		return
+	}
+
+	response := dto.TaskModelToResponse(patchedTask)
+
+	setTaskETag(w, patchedTask)
+	WriteJSONResponse(w, http.StatusOK, response)
+
+	slog.InfoContext(ctx, "Task patched via HTTP",
+		slog.String("task_id", response.ID),
+		slog.Int("operations", len(patch)),
+	)
+}
+
+func (h *TaskHandlers) updateTask(w http.ResponseWriter, r *http.Request, taskID string, req dto.UpdateTaskRequest) {
+	ctx := r.Context()
+
+	expectedVersion, ok := h.parseIfMatch(w, r, taskID, false)
+	if !ok {
+		return
+	}
+	req.ExpectedVersion = expectedVersion
+
+	updatedTask, err := h.taskService.UpdateTask(ctx, taskID, req)
 	if err != nil {
 		h.handleServiceError(w, err, "Failed to update task")
 		return
@@ -140,10 +309,13 @@ func (h *TaskHandlers) HandleUpdateTask(w http.ResponseWriter, r *http.Request)
 
 	response := dto.TaskModelToResponse(updatedTask)
 
+	setTaskETag(w, updatedTask)
 	WriteJSONResponse(w, http.StatusOK, response)
 
 	slog.InfoContext(ctx, "Task updated via HTTP",
 		slog.String("task_id", response.ID),
+		slog.String("method", r.Method),
+		slog.Any("fields", req.Paths()),
 	)
 }
 
@@ -158,27 +330,33 @@ func (h *TaskHandlers) HandleDeleteTask(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err := h.taskService.DeleteTask(ctx, taskID)
+	permanent, err := validator.ValidatePermanentParam(r.URL.Query().Get("permanent"))
+	if err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// A permanent delete may target a task that is already in the trash.
+	expectedVersion, ok := h.parseIfMatch(w, r, taskID, permanent)
+	if !ok {
+		return
+	}
+
+	err = h.taskService.DeleteTask(ctx, taskID, expectedVersion, permanent)
 	if err != nil {
 		h.handleServiceError(w, err, "Failed to delete task")
 		return
 	}
 
-	response := dto.DeleteTaskResponse{Success: true}
+	response := dto.DeleteTaskResponse{Success: true, Permanent: permanent}
 	WriteJSONResponse(w, http.StatusOK, response)
 
 	slog.InfoContext(ctx, "Task deleted via HTTP",
 		slog.String("task_id", taskID),
+		slog.Bool("permanent", permanent),
 	)
 }
 
 func (h *TaskHandlers) handleServiceError(w http.ResponseWriter, err error, defaultMessage string) {
-	statusCode := apiErrors.HTTPStatusFromError(err)
-	message := apiErrors.MessageFromError(err)
-
-	if message == "" || strings.Contains(message, "rpc") {
-		message = defaultMessage
-	}
-
-	WriteErrorResponse(w, message, statusCode)
+	writeServiceError(w, err, defaultMessage)
 }
\ No newline at end of file
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/internal/validator"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+)
+
+const batchAbortedMessage = "Not applied because another operation in the atomic batch failed"
+
+func (h *TaskHandlers) HandleBatchTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req dto.BatchTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := validator.ValidateBatchTaskRequest(req); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	results := make([]dto.BatchTaskResult, len(req.Operations))
+	var ops []dto.BatchTaskOperation
+	var opIndexes []int
+
+	for i, opReq := range req.Operations {
+		op, err := validator.ValidateBatchOperation(opReq)
+		if err != nil {
+			results[i] = dto.BatchTaskResult{Index: i, Status: http.StatusBadRequest, Error: err.Error()}
+			continue
+		}
+		ops = append(ops, op)
+		opIndexes = append(opIndexes, i)
+	}
+
+	switch {
+	case req.Atomic && len(ops) < len(req.Operations):
+		for _, i := range opIndexes {
+			results[i] = dto.BatchTaskResult{Index: i, Status: http.StatusConflict, Error: batchAbortedMessage}
+		}
+
+	case len(ops) > 0:
+		batchResults, err := h.taskService.BatchTasks(ctx, ops, req.Atomic)
+		if err != nil {
+			h.handleServiceError(w, err, "Failed to apply task batch")
+			return
+		}
+
+		for j, i := range opIndexes {
+			results[i] = batchResultToResponse(i, ops[j].Op, batchResults[j])
+		}
+	}
+
+	response := dto.BatchTaskResponse{
+		Atomic:  req.Atomic,
+		Results: results,
+	}
+	for _, result := range results {
+		if result.Error != "" {
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+	}
+
+	WriteJSONResponse(w, batchStatus(response), response)
+
+	slog.InfoContext(ctx, "Task batch processed via HTTP",
+		slog.Int("operations", len(results)),
+		slog.Int("succeeded", response.Succeeded),
+		slog.Int("failed", response.Failed),
+		slog.Bool("atomic", req.Atomic),
+	)
+}
+
+func batchResultToResponse(index int, op string, result model.BatchResult) dto.BatchTaskResult {
+	if result.Err != nil {
+		statusCode, message := serviceErrorStatus(result.Err, "Failed to "+op+" task")
+		return dto.BatchTaskResult{Index: index, Status: statusCode, Error: message}
+	}
+
+	response := dto.BatchTaskResult{Index: index, Status: http.StatusOK}
+	if op == dto.BatchOpCreate {
+		response.Status = http.StatusCreated
+	}
+	if result.Task != nil {
+		task := dto.TaskModelToResponse(result.Task)
+		response.Task = &task
+	}
+
+	return response
+}
+
+// batchStatus is 200 unless an atomic batch was rolled back, in which case it
+// reports the status of the operation that caused it.
+func batchStatus(response dto.BatchTaskResponse) int {
+	if !response.Atomic || response.Failed == 0 {
+		return http.StatusOK
+	}
+
+	for _, result := range response.Results {
+		if result.Error != "" && result.Status != http.StatusConflict {
+			return result.Status
+		}
+	}
+
+	return http.StatusConflict
+}
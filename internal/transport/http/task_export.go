@@ -0,0 +1,243 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/internal/validator"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+)
+
+// exportPageTimeout bounds how long writing a single page may take. The
+// deadline is pushed forward for every page, so long exports are not cut
+// off by the server's write timeout.
+const exportPageTimeout = 30 * time.Second
+
+var exportContentTypes = map[string]string{
+	validator.ExportFormatCSV:      "text/csv; charset=utf-8",
+	validator.ExportFormatNDJSON:   "application/x-ndjson",
+	validator.ExportFormatMarkdown: "text/markdown; charset=utf-8",
+}
+
+var csvExportHeader = []string{
+	"id", "title", "description", "completed", "priority", "due_at", "tags",
+	"list_id", "owner_id", "items_done", "items_total", "created_at", "updated_at",
+}
+
+type taskExporter interface {
+	begin() error
+	write(tasks []*model.Task) error
+}
+
+// HandleExportTasks streams every task matching the task list filters as
+// CSV, NDJSON or a Markdown checklist, up to the configured row limit.
+// Pages are written and flushed as they are loaded, so the export is never
+// held in memory.
+func (h *TaskHandlers) HandleExportTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	format, err := validator.ValidateExportFormatParam(query.Get("format"))
+	if err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	listReq, err := parseListTasksQuery(query)
+	if err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	exporter := newTaskExporter(format, w)
+	rc := http.NewResponseController(w)
+	started := false
+	count := 0
+
+	err = h.taskService.ExportTasks(ctx, listReq, func(tasks []*model.Task) error {
+		rc.SetWriteDeadline(time.Now().Add(exportPageTimeout))
+
+		if !started {
+			started = true
+			w.Header().Set("Content-Type", exportContentTypes[format])
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"tasks.%s\"", format))
+			w.Header().Set("X-Content-Type-Options", "nosniff")
+			w.WriteHeader(http.StatusOK)
+
+			if err := exporter.begin(); err != nil {
+				return err
+			}
+		}
+
+		if err := exporter.write(tasks); err != nil {
+			return err
+		}
+		count += len(tasks)
+
+		if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		if !started {
+			h.handleServiceError(w, err, "Failed to export tasks")
+			return
+		}
+
+		// The status line is already sent. Aborting the handler resets
+		// the connection instead of ending the chunked body cleanly, so
+		// clients see a failed download rather than a short export.
+		slog.ErrorContext(ctx, "Task export aborted",
+			slog.String("format", format),
+			slog.Int("count", count),
+			slog.String("error", err.Error()),
+		)
+		panic(http.ErrAbortHandler)
+	}
+
+	slog.InfoContext(ctx, "Tasks exported via HTTP",
+		slog.String("format", format),
+		slog.Int("count", count),
+	)
+}
+
+func newTaskExporter(format string, w io.Writer) taskExporter {
+	switch format {
+	case validator.ExportFormatNDJSON:
+		return &ndjsonTaskExporter{encoder: json.NewEncoder(w)}
+	case validator.ExportFormatMarkdown:
+		return &markdownTaskExporter{w: w}
+	default:
+		return &csvTaskExporter{w: csv.NewWriter(w)}
+	}
+}
+
+type csvTaskExporter struct {
+	w *csv.Writer
+}
+
+func (e *csvTaskExporter) begin() error {
+	e.w.Write(csvExportHeader)
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvTaskExporter) write(tasks []*model.Task) error {
+	for _, task := range tasks {
+		progress := task.Progress()
+
+		dueAt := ""
+		if task.DueAt != nil {
+			dueAt = task.DueAt.UTC().Format(time.RFC3339)
+		}
+
+		e.w.Write([]string{
+			task.ID,
+			csvText(task.Title),
+			csvText(task.Description),
+			strconv.FormatBool(task.Completed),
+			string(task.Priority),
+			dueAt,
+			csvText(strings.Join(task.Tags, ";")),
+			csvText(task.ListID),
+			csvText(task.OwnerID),
+			strconv.Itoa(progress.Done),
+			strconv.Itoa(progress.Total),
+			task.CreatedAt.UTC().Format(time.RFC3339),
+			task.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// csvText keeps user-supplied text from being run as a formula when the
+// export is opened in a spreadsheet, by prefixing cells that start with a
+// formula trigger with a single quote.
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+type ndjsonTaskExporter struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonTaskExporter) begin() error {
+	return nil
+}
+
+func (e *ndjsonTaskExporter) write(tasks []*model.Task) error {
+	for _, task := range tasks {
+		if err := e.encoder.Encode(dto.TaskModelToResponse(task)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markdownTaskExporter renders tasks as a checklist. Descriptions and
+// checklist items are indented under their task so they stay part of the
+// list item.
+type markdownTaskExporter struct {
+	w io.Writer
+}
+
+func (e *markdownTaskExporter) begin() error {
+	return nil
+}
+
+func (e *markdownTaskExporter) write(tasks []*model.Task) error {
+	var b strings.Builder
+
+	for _, task := range tasks {
+		b.WriteString(markdownCheckbox(task.Completed))
+		b.WriteString(markdownLine(task.Title))
+		b.WriteString("\n")
+
+		if description := strings.TrimSpace(task.Description); description != "" {
+			for _, line := range strings.Split(description, "\n") {
+				line = strings.TrimRight(line, " \t\r")
+				if line != "" {
+					b.WriteString("  ")
+					b.WriteString(line)
+				}
+				b.WriteString("\n")
+			}
+		}
+
+		for _, item := range task.Items {
+			b.WriteString("  ")
+			b.WriteString(markdownCheckbox(item.Done))
+			b.WriteString(markdownLine(item.Text))
+			b.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(e.w, b.String())
+	return err
+}
+
+func markdownCheckbox(done bool) string {
+	if done {
+		return "- [x] "
+	}
+	return "- [ ] "
+}
+
+func markdownLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+)
+
+// failingExportService writes one page and then fails, as ExportTasks does
+// when it hits the row limit mid-stream.
+type failingExportService struct {
+	fakeTaskService
+}
+
+func (s *failingExportService) ExportTasks(ctx context.Context, req dto.ListTasksRequest, write func([]*model.Task) error) error {
+	if err := write([]*model.Task{{ID: "task-1", Title: "Pack"}}); err != nil {
+		return err
+	}
+	return errors.New("export stopped at the limit of 1 tasks")
+}
+
+func TestHandleExportTasksAbortsTruncatedStream(t *testing.T) {
+	h := NewTaskHandlers(&failingExportService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/export?format=csv", nil)
+	rec := httptest.NewRecorder()
+
+	defer func() {
+		if got := recover(); got != http.ErrAbortHandler {
+			t.Fatalf("recovered %v, want http.ErrAbortHandler so the client sees the truncation", got)
+		}
+		if rec.Code != http.StatusOK || rec.Body.Len() == 0 {
+			t.Errorf("status = %d with %d bytes, want the first page streamed before the abort", rec.Code, rec.Body.Len())
+		}
+	}()
+
+	h.HandleExportTasks(rec, req)
+}
+
+func TestCSVText(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "", want: ""},
+		{value: "Pack", want: "Pack"},
+		{value: "=HYPERLINK(\"http://example.com\")", want: "'=HYPERLINK(\"http://example.com\")"},
+		{value: "+1", want: "'+1"},
+		{value: "-1", want: "'-1"},
+		{value: "@SUM(A1)", want: "'@SUM(A1)"},
+		{value: "\t=1", want: "'\t=1"},
+		{value: "a=1", want: "a=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := csvText(tt.value); got != tt.want {
+				t.Errorf("csvText(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVTaskExporterEscapesFormulas(t *testing.T) {
+	var buf bytes.Buffer
+	exporter := &csvTaskExporter{w: csv.NewWriter(&buf)}
+
+	task := &model.Task{ID: "task-1", Title: "=cmd|' /C calc'!A0", Description: "@risk", Tags: []string{"+tag", "ok"}, OwnerID: "alice"}
+	if err := exporter.write([]*model.Task{task}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	row := records[0]
+	if row[1] != "'=cmd|' /C calc'!A0" || row[2] != "'@risk" || row[6] != "'+tag;ok" {
+		t.Errorf("row = %q, want the title, description and tags prefixed with '", row)
+	}
+	if row[0] != "task-1" || row[8] != "alice" {
+		t.Errorf("row = %q, want plain id and owner", row)
+	}
+}
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/validator"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+
+	"github.com/gorilla/mux"
+)
+
+func (h *TaskHandlers) HandleGetTaskHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := mux.Vars(r)["id"]
+	if err := validator.ValidateTaskID(taskID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	revisions, err := h.taskService.GetTaskHistory(ctx, taskID)
+	if err != nil {
+		h.handleServiceError(w, err, "Failed to get task history")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, dto.RevisionsToHistoryResponse(taskID, revisions))
+
+	slog.InfoContext(ctx, "Task history retrieved via HTTP",
+		slog.String("task_id", taskID),
+		slog.Int("count", len(revisions)),
+	)
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/validator"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+
+	"github.com/gorilla/mux"
+)
+
+// HandleGetTrash lists deleted tasks that have not been purged yet. It
+// accepts the same query parameters as the task list.
+func (h *TaskHandlers) HandleGetTrash(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	listReq, err := parseListTasksQuery(r.URL.Query())
+	if err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	listReq.Deleted = true
+
+	page, err := h.taskService.GetTasks(ctx, listReq)
+	if err != nil {
+		h.handleServiceError(w, err, "Failed to get trash")
+		return
+	}
+
+	response := dto.TaskModelsToResponse(page.Tasks)
+	response.TotalCount = page.TotalCount
+	response.NextCursor = page.NextCursor
+
+	setPaginationLinks(w, r, listReq.Limit, page.NextCursor)
+	WriteJSONResponse(w, http.StatusOK, response)
+
+	slog.InfoContext(ctx, "Trash retrieved via HTTP",
+		slog.Int("count", len(page.Tasks)),
+		slog.Int("total_count", page.TotalCount),
+	)
+}
+
+func (h *TaskHandlers) HandleRestoreTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := mux.Vars(r)["id"]
+	if err := validator.ValidateTaskID(taskID); err != nil {
+		WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	task, err := h.taskService.RestoreTask(ctx, taskID)
+	if err != nil {
+		h.handleServiceError(w, err, "Failed to restore task")
+		return
+	}
+
+	response := dto.TaskModelToResponse(task)
+
+	setTaskETag(w, task)
+	WriteJSONResponse(w, http.StatusOK, response)
+
+	slog.InfoContext(ctx, "Task restored via HTTP",
+		slog.String("task_id", response.ID),
+	)
+}
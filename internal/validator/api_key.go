@@ -0,0 +1,50 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/auth"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+)
+
+var (
+	ErrAPIKeyIDRequired     = errors.New("API key ID is required")
+	ErrAPIKeyNameRequired   = errors.New("API key name is required")
+	ErrAPIKeyNameTooLong    = errors.New("API key name is too long (max 100 characters)")
+	ErrAPIKeyScopesRequired = errors.New("At least one scope is required")
+	ErrInvalidScope         = fmt.Errorf("Invalid scope. Allowed values: %s", strings.Join(auth.Scopes, ", "))
+
+	MaxAPIKeyNameLength = 100
+)
+
+func ValidateAPIKeyID(keyID string) error {
+	if keyID == "" {
+		return ErrAPIKeyIDRequired
+	}
+
+	return nil
+}
+
+func ValidateCreateAPIKeyRequest(req dto.CreateAPIKeyRequest) error {
+	if req.Name == "" {
+		return ErrAPIKeyNameRequired
+	}
+
+	if len(req.Name) > MaxAPIKeyNameLength {
+		return ErrAPIKeyNameTooLong
+	}
+
+	if len(req.Scopes) == 0 {
+		return ErrAPIKeyScopesRequired
+	}
+
+	for _, scope := range req.Scopes {
+		if !auth.IsValidScope(scope) {
+			return ErrInvalidScope
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+)
+
+var (
+	ErrBatchEmpty           = errors.New("At least one operation is required")
+	ErrBatchTooLarge        = fmt.Errorf("Too many operations (max %d per batch)", MaxBatchOperations)
+	ErrInvalidBatchOp       = errors.New("Invalid 'op'. Allowed values: create, update, delete")
+	ErrBatchTaskRequired    = errors.New("'task' is required for create and update operations")
+	ErrInvalidBatchTask     = errors.New("Invalid 'task' format")
+	ErrBatchVersionOnCreate = errors.New("'version' is only allowed for update and delete operations")
+	ErrInvalidBatchVersion  = errors.New("Invalid 'version'. Use a non-negative integer")
+	ErrBatchDuplicateTaskID = errors.New("Each task may appear in at most one update or delete operation per batch")
+
+	MaxBatchOperations = 500
+)
+
+func ValidateBatchTaskRequest(req dto.BatchTaskRequest) error {
+	if len(req.Operations) == 0 {
+		return ErrBatchEmpty
+	}
+
+	if len(req.Operations) > MaxBatchOperations {
+		return ErrBatchTooLarge
+	}
+
+	// Every operation is diffed against the task as it was before the batch,
+	// so a second operation on the same task would be recorded wrongly.
+	seen := make(map[string]bool)
+	for _, op := range req.Operations {
+		if op.Op == dto.BatchOpCreate || op.ID == "" {
+			continue
+		}
+		if seen[op.ID] {
+			return fmt.Errorf("%w: '%s'", ErrBatchDuplicateTaskID, op.ID)
+		}
+		seen[op.ID] = true
+	}
+
+	return nil
+}
+
+// ValidateBatchOperation decodes the task payload of a batch entry and runs
+// the same checks as the single-task endpoints.
+func ValidateBatchOperation(req dto.BatchTaskOperationRequest) (dto.BatchTaskOperation, error) {
+	op := dto.BatchTaskOperation{Op: req.Op, TaskID: req.ID}
+
+	if req.Version != nil && *req.Version < 0 {
+		return op, ErrInvalidBatchVersion
+	}
+
+	switch req.Op {
+	case dto.BatchOpCreate:
+		if req.Version != nil {
+			return op, ErrBatchVersionOnCreate
+		}
+
+		var create dto.CreateTaskRequest
+		if err := decodeBatchTask(req.Task, &create); err != nil {
+			return op, err
+		}
+		if err := ValidateCreateTaskRequest(create); err != nil {
+			return op, err
+		}
+		op.Create = &create
+
+	case dto.BatchOpUpdate:
+		if err := ValidateTaskID(req.ID); err != nil {
+			return op, err
+		}
+
+		var update dto.UpdateTaskRequest
+		if err := decodeBatchTask(req.Task, &update); err != nil {
+			return op, err
+		}
+		if err := ValidateUpdateTaskRequest(update); err != nil {
+			return op, err
+		}
+		op.Update = &update
+		op.ExpectedVersion = req.Version
+
+	case dto.BatchOpDelete:
+		if err := ValidateTaskID(req.ID); err != nil {
+			return op, err
+		}
+		op.ExpectedVersion = req.Version
+		op.Permanent = req.Permanent
+
+	default:
+		return op, ErrInvalidBatchOp
+	}
+
+	return op, nil
+}
+
+func decodeBatchTask(raw json.RawMessage, target any) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ErrBatchTaskRequired
+	}
+
+	if err := json.Unmarshal(raw, target); err != nil {
+		return ErrInvalidBatchTask
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+)
+
+func TestValidateBatchTaskRequest(t *testing.T) {
+	tooMany := make([]dto.BatchTaskOperationRequest, MaxBatchOperations+1)
+	for i := range tooMany {
+		tooMany[i] = dto.BatchTaskOperationRequest{Op: dto.BatchOpCreate}
+	}
+
+	tests := []struct {
+		name    string
+		ops     []dto.BatchTaskOperationRequest
+		wantErr error
+	}{
+		{name: "empty", wantErr: ErrBatchEmpty},
+		{name: "too many", ops: tooMany, wantErr: ErrBatchTooLarge},
+		{
+			name: "distinct tasks",
+			ops: []dto.BatchTaskOperationRequest{
+				{Op: dto.BatchOpUpdate, ID: "task-1"},
+				{Op: dto.BatchOpDelete, ID: "task-2"},
+				{Op: dto.BatchOpCreate},
+				{Op: dto.BatchOpCreate},
+			},
+		},
+		{
+			name: "update twice",
+			ops: []dto.BatchTaskOperationRequest{
+				{Op: dto.BatchOpUpdate, ID: "task-1"},
+				{Op: dto.BatchOpUpdate, ID: "task-1"},
+			},
+			wantErr: ErrBatchDuplicateTaskID,
+		},
+		{
+			name: "update then delete",
+			ops: []dto.BatchTaskOperationRequest{
+				{Op: dto.BatchOpUpdate, ID: "task-1"},
+				{Op: dto.BatchOpDelete, ID: "task-1"},
+			},
+			wantErr: ErrBatchDuplicateTaskID,
+		},
+		{
+			name: "missing ids are left to the per-operation checks",
+			ops: []dto.BatchTaskOperationRequest{
+				{Op: dto.BatchOpUpdate},
+				{Op: dto.BatchOpDelete},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBatchTaskRequest(dto.BatchTaskRequest{Operations: tt.ops})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateBatchTaskRequest() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package validator
+
+import (
+	"errors"
+
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+)
+
+var (
+	ErrItemIDRequired       = errors.New("Checklist item ID is required")
+	ErrItemTextRequired     = errors.New("Checklist item text is required")
+	ErrItemTextEmpty        = errors.New("Checklist item text cannot be empty")
+	ErrItemTextTooLong      = errors.New("Checklist item text is too long (max 500 characters)")
+	ErrInvalidItemPosition  = errors.New("Checklist item position cannot be negative")
+	ErrNoItemFieldsProvided = errors.New("At least one field must be provided for checklist item update")
+	ErrItemIDsRequired      = errors.New("At least one checklist item ID is required for reordering")
+	ErrDuplicateItemID      = errors.New("Checklist item IDs must be unique")
+
+	MaxItemTextLength = 500
+)
+
+func ValidateItemID(itemID string) error {
+	if itemID == "" {
+		return ErrItemIDRequired
+	}
+
+	return nil
+}
+
+func ValidateCreateChecklistItemRequest(req dto.CreateChecklistItemRequest) error {
+	if req.Text == "" {
+		return ErrItemTextRequired
+	}
+
+	if len(req.Text) > MaxItemTextLength {
+		return ErrItemTextTooLong
+	}
+
+	if req.Position != nil && *req.Position < 0 {
+		return ErrInvalidItemPosition
+	}
+
+	return nil
+}
+
+func ValidateUpdateChecklistItemRequest(req dto.UpdateChecklistItemRequest) error {
+	if req.Text == nil && req.Done == nil && req.Position == nil {
+		return ErrNoItemFieldsProvided
+	}
+
+	if req.Text != nil {
+		if *req.Text == "" {
+			return ErrItemTextEmpty
+		}
+		if len(*req.Text) > MaxItemTextLength {
+			return ErrItemTextTooLong
+		}
+	}
+
+	if req.Position != nil && *req.Position < 0 {
+		return ErrInvalidItemPosition
+	}
+
+	return nil
+}
+
+func ValidateReorderChecklistItemsRequest(req dto.ReorderChecklistItemsRequest) error {
+	if len(req.ItemIDs) == 0 {
+		return ErrItemIDsRequired
+	}
+
+	seen := make(map[string]bool, len(req.ItemIDs))
+	for _, itemID := range req.ItemIDs {
+		if err := ValidateItemID(itemID); err != nil {
+			return err
+		}
+		if seen[itemID] {
+			return ErrDuplicateItemID
+		}
+		seen[itemID] = true
+	}
+
+	return nil
+}
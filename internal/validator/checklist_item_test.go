@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+)
+
+func TestValidateCreateChecklistItemRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     dto.CreateChecklistItemRequest
+		wantErr error
+	}{
+		{name: "text only", req: dto.CreateChecklistItemRequest{Text: "Passport"}},
+		{name: "done with position", req: dto.CreateChecklistItemRequest{Text: "Passport", Done: true, Position: ptr(0)}},
+		{name: "text at the limit", req: dto.CreateChecklistItemRequest{Text: strings.Repeat("a", MaxItemTextLength)}},
+		{name: "missing text", req: dto.CreateChecklistItemRequest{Done: true}, wantErr: ErrItemTextRequired},
+		{name: "text too long", req: dto.CreateChecklistItemRequest{Text: strings.Repeat("a", MaxItemTextLength+1)}, wantErr: ErrItemTextTooLong},
+		{name: "negative position", req: dto.CreateChecklistItemRequest{Text: "Passport", Position: ptr(-1)}, wantErr: ErrInvalidItemPosition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateCreateChecklistItemRequest(tt.req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateUpdateChecklistItemRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     dto.UpdateChecklistItemRequest
+		wantErr error
+	}{
+		{name: "tick off", req: dto.UpdateChecklistItemRequest{Done: ptr(true)}},
+		{name: "rename", req: dto.UpdateChecklistItemRequest{Text: ptr("Charger")}},
+		{name: "move", req: dto.UpdateChecklistItemRequest{Position: ptr(2)}},
+		{name: "no fields", req: dto.UpdateChecklistItemRequest{}, wantErr: ErrNoItemFieldsProvided},
+		{name: "empty text", req: dto.UpdateChecklistItemRequest{Text: ptr("")}, wantErr: ErrItemTextEmpty},
+		{name: "text too long", req: dto.UpdateChecklistItemRequest{Text: ptr(strings.Repeat("a", MaxItemTextLength+1))}, wantErr: ErrItemTextTooLong},
+		{name: "negative position", req: dto.UpdateChecklistItemRequest{Position: ptr(-1)}, wantErr: ErrInvalidItemPosition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateUpdateChecklistItemRequest(tt.req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateReorderChecklistItemsRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		itemIDs []string
+		wantErr error
+	}{
+		{name: "new order", itemIDs: []string{"item-2", "item-1"}},
+		{name: "single item", itemIDs: []string{"item-1"}},
+		{name: "empty", itemIDs: nil, wantErr: ErrItemIDsRequired},
+		{name: "blank id", itemIDs: []string{"item-1", ""}, wantErr: ErrItemIDRequired},
+		{name: "duplicate id", itemIDs: []string{"item-1", "item-2", "item-1"}, wantErr: ErrDuplicateItemID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := dto.ReorderChecklistItemsRequest{ItemIDs: tt.itemIDs}
+			if err := ValidateReorderChecklistItemsRequest(req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package validator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	ExportFormatCSV      = "csv"
+	ExportFormatNDJSON   = "ndjson"
+	ExportFormatMarkdown = "md"
+)
+
+var (
+	ExportFormats = []string{ExportFormatCSV, ExportFormatNDJSON, ExportFormatMarkdown}
+
+	ErrInvalidFormatParameter = fmt.Errorf("Invalid 'format' parameter. Allowed values: %s", strings.Join(ExportFormats, ", "))
+)
+
+func ValidateExportFormatParam(formatStr string) (string, error) {
+	if formatStr == "" {
+		return ExportFormatCSV, nil
+	}
+
+	if !slices.Contains(ExportFormats, formatStr) {
+		return "", ErrInvalidFormatParameter
+	}
+
+	return formatStr, nil
+}
@@ -0,0 +1,60 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/jsonpatch"
+)
+
+var (
+	ErrJSONPatchEmpty         = errors.New("JSON Patch must contain at least one operation")
+	ErrJSONPatchTooLarge      = fmt.Errorf("Too many JSON Patch operations (max %d)", MaxJSONPatchOperations)
+	ErrInvalidJSONPatchOp     = errors.New("Invalid JSON Patch 'op'. Allowed values: add, remove, replace, move, copy, test")
+	ErrInvalidJSONPatchPath   = errors.New("Invalid JSON Patch 'path'. Use a JSON pointer such as '/title'")
+	ErrJSONPatchFromRequired  = errors.New("JSON Patch 'from' is required for move and copy operations")
+	ErrInvalidJSONPatchFrom   = errors.New("Invalid JSON Patch 'from'. Use a JSON pointer such as '/title'")
+	ErrJSONPatchValueRequired = errors.New("JSON Patch 'value' is required for add, replace and test operations")
+
+	MaxJSONPatchOperations = 100
+)
+
+func ValidateJSONPatch(ops []jsonpatch.Operation) error {
+	if len(ops) == 0 {
+		return ErrJSONPatchEmpty
+	}
+
+	if len(ops) > MaxJSONPatchOperations {
+		return ErrJSONPatchTooLarge
+	}
+
+	for _, op := range ops {
+		if !validJSONPointer(op.Path) {
+			return ErrInvalidJSONPatchPath
+		}
+
+		switch op.Op {
+		case jsonpatch.OpAdd, jsonpatch.OpReplace, jsonpatch.OpTest:
+			if op.Value == nil {
+				return ErrJSONPatchValueRequired
+			}
+		case jsonpatch.OpMove, jsonpatch.OpCopy:
+			if op.From == nil {
+				return ErrJSONPatchFromRequired
+			}
+			if !validJSONPointer(*op.From) {
+				return ErrInvalidJSONPatchFrom
+			}
+		case jsonpatch.OpRemove:
+		default:
+			return ErrInvalidJSONPatchOp
+		}
+	}
+
+	return nil
+}
+
+func validJSONPointer(pointer string) bool {
+	return pointer == "" || strings.HasPrefix(pointer, "/")
+}
@@ -0,0 +1,84 @@
+package validator
+
+import (
+	"errors"
+
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+)
+
+var (
+	ErrListIDRequired          = errors.New("List ID is required")
+	ErrInvalidListID           = errors.New("Invalid list ID")
+	ErrListNameRequired        = errors.New("List name is required")
+	ErrListNameEmpty           = errors.New("List name cannot be empty")
+	ErrListNameTooLong         = errors.New("List name is too long (max 100 characters)")
+	ErrNoListFieldsProvided    = errors.New("At least one field must be provided for list update")
+	ErrInvalidCascadeParameter = errors.New("Invalid 'cascade' parameter. Use 'true' or 'false'")
+
+	MaxListNameLength = 100
+	MaxListIDLength   = 128
+)
+
+func ValidateListID(listID string) error {
+	if listID == "" {
+		return ErrListIDRequired
+	}
+
+	if len(listID) > MaxListIDLength {
+		return ErrInvalidListID
+	}
+
+	for _, r := range listID {
+		if r <= ' ' || r == 0x7f {
+			return ErrInvalidListID
+		}
+	}
+
+	return nil
+}
+
+func ValidateCreateListRequest(req dto.CreateListRequest) error {
+	if req.Name == "" {
+		return ErrListNameRequired
+	}
+
+	if len(req.Name) > MaxListNameLength {
+		return ErrListNameTooLong
+	}
+
+	if len(req.Description) > MaxDescriptionLength {
+		return ErrDescriptionTooLong
+	}
+
+	return nil
+}
+
+func ValidateUpdateListRequest(req dto.UpdateListRequest) error {
+	if req.IsEmpty() {
+		return ErrNoListFieldsProvided
+	}
+
+	if req.Name != nil {
+		if *req.Name == "" {
+			return ErrListNameEmpty
+		}
+		if len(*req.Name) > MaxListNameLength {
+			return ErrListNameTooLong
+		}
+	}
+
+	if req.Description != nil && len(*req.Description) > MaxDescriptionLength {
+		return ErrDescriptionTooLong
+	}
+
+	return nil
+}
+
+func ValidateCascadeParam(cascadeStr string) (bool, error) {
+	cascade, err := parseBoolParam(cascadeStr, ErrInvalidCascadeParameter)
+	if err != nil || cascade == nil {
+		return false, err
+	}
+
+	return *cascade, nil
+}
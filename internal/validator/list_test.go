@@ -0,0 +1,102 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+)
+
+func TestValidateListID(t *testing.T) {
+	tests := []struct {
+		name    string
+		listID  string
+		wantErr error
+	}{
+		{name: "uuid", listID: "5f1c2a8e-6d0b-4a57-9a41-1b2f3c4d5e6f"},
+		{name: "empty", listID: "", wantErr: ErrListIDRequired},
+		{name: "too long", listID: strings.Repeat("a", MaxListIDLength+1), wantErr: ErrInvalidListID},
+		{name: "space", listID: "my list", wantErr: ErrInvalidListID},
+		{name: "newline", listID: "list\n", wantErr: ErrInvalidListID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateListID(tt.listID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCreateListRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     dto.CreateListRequest
+		wantErr error
+	}{
+		{name: "name only", req: dto.CreateListRequest{Name: "Groceries"}},
+		{name: "with description", req: dto.CreateListRequest{Name: "Groceries", Description: "Weekly shop"}},
+		{name: "missing name", req: dto.CreateListRequest{Description: "Weekly shop"}, wantErr: ErrListNameRequired},
+		{name: "name too long", req: dto.CreateListRequest{Name: strings.Repeat("a", MaxListNameLength+1)}, wantErr: ErrListNameTooLong},
+		{name: "description too long", req: dto.CreateListRequest{Name: "Groceries", Description: strings.Repeat("a", MaxDescriptionLength+1)}, wantErr: ErrDescriptionTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateCreateListRequest(tt.req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateUpdateListRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     dto.UpdateListRequest
+		wantErr error
+	}{
+		{name: "rename", req: dto.UpdateListRequest{Name: ptr("Errands")}},
+		{name: "clear description", req: dto.UpdateListRequest{Description: ptr("")}},
+		{name: "no fields", req: dto.UpdateListRequest{}, wantErr: ErrNoListFieldsProvided},
+		{name: "empty name", req: dto.UpdateListRequest{Name: ptr("")}, wantErr: ErrListNameEmpty},
+		{name: "name too long", req: dto.UpdateListRequest{Name: ptr(strings.Repeat("a", MaxListNameLength+1))}, wantErr: ErrListNameTooLong},
+		{name: "description too long", req: dto.UpdateListRequest{Description: ptr(strings.Repeat("a", MaxDescriptionLength+1))}, wantErr: ErrDescriptionTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateUpdateListRequest(tt.req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCascadeParam(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    bool
+		wantErr error
+	}{
+		{value: "", want: false},
+		{value: "true", want: true},
+		{value: "false", want: false},
+		{value: "1", wantErr: ErrInvalidCascadeParameter},
+		{value: "yes", wantErr: ErrInvalidCascadeParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ValidateCascadeParam(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("cascade = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
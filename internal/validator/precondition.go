@@ -0,0 +1,61 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidIfMatchHeader = errors.New("Invalid 'If-Match' header. Use '*' or a comma-separated list of entity tags from the ETag header")
+	ErrPreconditionFailed   = errors.New("Precondition failed: the task has been modified")
+)
+
+// ValidateIfMatchHeader returns the strong entity tags listed in If-Match,
+// quoted as they appear in the ETag header, or nil when the header is absent
+// or '*'. Weak entity tags never match under the strong comparison If-Match
+// requires (RFC 9110, section 13.1.1), so a list of only weak tags fails the
+// precondition outright.
+func ValidateIfMatchHeader(value string) ([]string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "*" {
+		return nil, nil
+	}
+
+	var tags []string
+	weak := false
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if strings.HasPrefix(entry, "W/") {
+			if !isOpaqueTag(strings.TrimPrefix(entry, "W/")) {
+				return nil, ErrInvalidIfMatchHeader
+			}
+			weak = true
+			continue
+		}
+
+		if !isOpaqueTag(entry) {
+			return nil, ErrInvalidIfMatchHeader
+		}
+		tags = append(tags, entry)
+	}
+
+	if len(tags) == 0 {
+		if weak {
+			return nil, ErrPreconditionFailed
+		}
+		return nil, ErrInvalidIfMatchHeader
+	}
+
+	return tags, nil
+}
+
+func isOpaqueTag(tag string) bool {
+	if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+		return false
+	}
+	return !strings.Contains(tag[1:len(tag)-1], `"`)
+}
@@ -0,0 +1,46 @@
+package validator
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestValidateIfMatchHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		wantTags []string
+		wantErr  error
+	}{
+		{name: "absent"},
+		{name: "any", header: "*"},
+		{name: "any with spaces", header: "  *  "},
+		{name: "single tag", header: `"7"`, wantTags: []string{`"7"`}},
+		{name: "list", header: `"7", "8"`, wantTags: []string{`"7"`, `"8"`}},
+		{name: "list without spaces", header: `"7","8"`, wantTags: []string{`"7"`, `"8"`}},
+		{name: "weak tags are skipped", header: `W/"6", "7"`, wantTags: []string{`"7"`}},
+		{name: "empty entries are skipped", header: `"7", , "8",`, wantTags: []string{`"7"`, `"8"`}},
+		{name: "empty opaque tag", header: `""`, wantTags: []string{`""`}},
+		{name: "only weak tags", header: `W/"6", W/"7"`, wantErr: ErrPreconditionFailed},
+		{name: "unquoted", header: "7", wantErr: ErrInvalidIfMatchHeader},
+		{name: "unquoted in list", header: `"7", 8`, wantErr: ErrInvalidIfMatchHeader},
+		{name: "unterminated", header: `"7`, wantErr: ErrInvalidIfMatchHeader},
+		{name: "inner quote", header: `"7"8"`, wantErr: ErrInvalidIfMatchHeader},
+		{name: "malformed weak tag", header: `W/7, "8"`, wantErr: ErrInvalidIfMatchHeader},
+		{name: "star in list", header: `*, "7"`, wantErr: ErrInvalidIfMatchHeader},
+		{name: "only commas", header: ", ,", wantErr: ErrInvalidIfMatchHeader},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags, err := ValidateIfMatchHeader(tt.header)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(tags, tt.wantTags) {
+				t.Errorf("tags = %q, want %q", tags, tt.wantTags)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package validator
+
+import (
+	"errors"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+)
+
+var (
+	ErrShareUserIDRequired = errors.New("User ID is required")
+	ErrShareUserIDTooLong  = errors.New("User ID is too long (max 255 characters)")
+	ErrInvalidShareRole    = errors.New("Invalid role. Allowed values: viewer, editor, owner")
+
+	MaxUserIDLength = 255
+)
+
+func ValidateShareUserID(userID string) error {
+	if userID == "" {
+		return ErrShareUserIDRequired
+	}
+
+	if len(userID) > MaxUserIDLength {
+		return ErrShareUserIDTooLong
+	}
+
+	return nil
+}
+
+func ValidateShareTaskRequest(req dto.ShareTaskRequest) error {
+	if err := ValidateShareUserID(req.UserID); err != nil {
+		return err
+	}
+
+	if _, ok := model.ParseRole(req.Role); !ok {
+		return ErrInvalidShareRole
+	}
+
+	return nil
+}
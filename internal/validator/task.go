@@ -2,7 +2,14 @@ package validator
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
 	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
 )
 
@@ -14,9 +21,34 @@ var (
 	ErrTaskIDRequired            = errors.New("Task ID is required")
 	ErrNoFieldsProvided          = errors.New("At least one field must be provided for update")
 	ErrInvalidCompletedParameter = errors.New("Invalid 'completed' parameter. Use 'true' or 'false'")
+	ErrInvalidLimitParameter     = errors.New("Invalid 'limit' parameter. Use an integer between 1 and 100")
+	ErrInvalidCursorParameter    = errors.New("Invalid 'cursor' parameter")
+	ErrInvalidSortParameter      = errors.New("Invalid 'sort' parameter")
+	ErrSearchQueryTooLong        = errors.New("Search query is too long (max 200 characters)")
+	ErrInvalidTimestampFormat    = errors.New("Use an RFC 3339 timestamp")
+	ErrInvalidTimeRange          = errors.New("Invalid time range: the '_after' bound must be earlier than the '_before' bound")
+	ErrDueDateOutOfRange         = errors.New("Due date is out of range (must be after 1970-01-01 and within 100 years from now)")
+	ErrInvalidOverdueParameter   = errors.New("Invalid 'overdue' parameter. Use 'true' or 'false'")
+	ErrInvalidPriority           = fmt.Errorf("Invalid priority. Allowed values: %s", joinPriorities())
+	ErrInvalidPriorityParameter  = fmt.Errorf("Invalid 'priority' parameter. Allowed values: %s", joinPriorities())
+	ErrTooManyTags               = errors.New("Too many tags (max 20 per task)")
+	ErrTagTooLong                = errors.New("Tag is too long (max 50 characters)")
+	ErrInvalidTagMatchParameter  = errors.New("Invalid 'tag_match' parameter. Use 'any' or 'all'")
+	ErrInvalidPermanentParameter = errors.New("Invalid 'permanent' parameter. Use 'true' or 'false'")
 
 	MaxTitleLength       = 255
 	MaxDescriptionLength = 1000
+	DefaultPageSize      = 50
+	MaxPageSize          = 100
+	MaxCursorLength      = 512
+	MaxSearchQueryLength = 200
+	MinDueDate           = time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
+	MaxDueDateHorizon    = 100 * 365 * 24 * time.Hour
+	MaxTagsPerTask       = 20
+	MaxTagLength         = 50
+
+	SortableFields = []string{"id", "title", "completed", "created_at", "updated_at", "due_at", "priority"}
+	DefaultSort    = []dto.SortField{{Field: "created_at"}, {Field: "id"}}
 )
 
 func ValidateCreateTaskRequest(req dto.CreateTaskRequest) error {
@@ -32,14 +64,32 @@ func ValidateCreateTaskRequest(req dto.CreateTaskRequest) error {
 		return ErrDescriptionTooLong
 	}
 
+	if err := ValidateDueAt(req.DueAt); err != nil {
+		return err
+	}
+
+	if req.Priority != "" {
+		if err := ValidatePriority(req.Priority); err != nil {
+			return err
+		}
+	}
+
+	if err := ValidateTags(req.Tags); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func ValidateUpdateTaskRequest(req dto.UpdateTaskRequest) error {
-	if req.Title == nil && req.Description == nil && req.Completed == nil {
+	if req.IsEmpty() {
 		return ErrNoFieldsProvided
 	}
 
+	if req.Title == nil && req.HasPath(dto.TaskFieldTitle) {
+		return ErrTitleEmpty
+	}
+
 	if req.Title != nil {
 		if *req.Title == "" {
 			return ErrTitleEmpty
@@ -53,6 +103,58 @@ func ValidateUpdateTaskRequest(req dto.UpdateTaskRequest) error {
 		return ErrDescriptionTooLong
 	}
 
+	if err := ValidateDueAt(req.DueAt); err != nil {
+		return err
+	}
+
+	if req.Priority != nil {
+		if err := ValidatePriority(*req.Priority); err != nil {
+			return err
+		}
+	}
+
+	if req.Tags != nil {
+		if err := ValidateTags(*req.Tags); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ValidateTags(tags []string) error {
+	normalized := model.NormalizeTags(tags)
+
+	if len(normalized) > MaxTagsPerTask {
+		return ErrTooManyTags
+	}
+
+	for _, tag := range normalized {
+		if utf8.RuneCountInString(tag) > MaxTagLength {
+			return ErrTagTooLong
+		}
+	}
+
+	return nil
+}
+
+func ValidatePriority(priority string) error {
+	if _, ok := model.ParsePriority(priority); !ok {
+		return ErrInvalidPriority
+	}
+
+	return nil
+}
+
+func ValidateDueAt(dueAt *time.Time) error {
+	if dueAt == nil {
+		return nil
+	}
+
+	if dueAt.Before(MinDueDate) || dueAt.After(time.Now().Add(MaxDueDateHorizon)) {
+		return ErrDueDateOutOfRange
+	}
+
 	return nil
 }
 
@@ -65,18 +167,180 @@ func ValidateTaskID(taskID string) error {
 }
 
 func ValidateCompletedParam(completedStr string) (*bool, error) {
-	if completedStr == "" {
+	return parseBoolParam(completedStr, ErrInvalidCompletedParameter)
+}
+
+func ValidateOverdueParam(overdueStr string) (*bool, error) {
+	return parseBoolParam(overdueStr, ErrInvalidOverdueParameter)
+}
+
+func ValidatePermanentParam(permanentStr string) (bool, error) {
+	permanent, err := parseBoolParam(permanentStr, ErrInvalidPermanentParameter)
+	if err != nil || permanent == nil {
+		return false, err
+	}
+
+	return *permanent, nil
+}
+
+func parseBoolParam(value string, errInvalid error) (*bool, error) {
+	if value == "" {
 		return nil, nil
 	}
 
-	switch completedStr {
+	switch value {
 	case "true":
-		completed := true
-		return &completed, nil
+		result := true
+		return &result, nil
 	case "false":
-		completed := false
-		return &completed, nil
+		result := false
+		return &result, nil
+	default:
+		return nil, errInvalid
+	}
+}
+
+func ValidateLimitParam(limitStr string) (int, error) {
+	if limitStr == "" {
+		return DefaultPageSize, nil
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 1 || limit > MaxPageSize {
+		return 0, ErrInvalidLimitParameter
+	}
+
+	return limit, nil
+}
+
+func ValidateCursorParam(cursor string) (string, error) {
+	if len(cursor) > MaxCursorLength {
+		return "", ErrInvalidCursorParameter
+	}
+
+	return cursor, nil
+}
+
+func ValidateSortParam(sortStr string) ([]dto.SortField, error) {
+	if sortStr == "" {
+		return DefaultSort, nil
+	}
+
+	var fields []dto.SortField
+	seen := make(map[string]bool)
+	hasID := false
+
+	for _, key := range strings.Split(sortStr, ",") {
+		key = strings.TrimSpace(key)
+		descending := strings.HasPrefix(key, "-")
+		key = strings.TrimPrefix(key, "-")
+
+		if key == "" {
+			return nil, fmt.Errorf("%w: empty sort key", ErrInvalidSortParameter)
+		}
+		if !slices.Contains(SortableFields, key) {
+			return nil, fmt.Errorf("%w: unknown sort key '%s' (allowed: %s)",
+				ErrInvalidSortParameter, key, strings.Join(SortableFields, ", "))
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("%w: duplicate sort key '%s'", ErrInvalidSortParameter, key)
+		}
+		seen[key] = true
+
+		if key == "id" {
+			hasID = true
+		}
+		fields = append(fields, dto.SortField{Field: key, Descending: descending})
+	}
+
+	if !hasID {
+		fields = append(fields, dto.SortField{Field: "id"})
+	}
+
+	return fields, nil
+}
+
+func ValidateSearchParam(query string) (string, error) {
+	query = strings.TrimSpace(query)
+
+	if len(query) > MaxSearchQueryLength {
+		return "", ErrSearchQueryTooLong
+	}
+
+	return query, nil
+}
+
+func ValidateTimestampParam(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	// An unescaped '+' in a query string decodes to a space, so restore it
+	// before parsing offsets such as "+03:00".
+	value = strings.ReplaceAll(value, " ", "+")
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid '%s' parameter. %w", name, ErrInvalidTimestampFormat)
+	}
+
+	return &parsed, nil
+}
+
+func ValidateTimeRange(after, before *time.Time) error {
+	if after != nil && before != nil && !after.Before(*before) {
+		return ErrInvalidTimeRange
+	}
+
+	return nil
+}
+
+func ValidatePriorityParam(priorityStr string) ([]model.Priority, error) {
+	if priorityStr == "" {
+		return nil, nil
+	}
+
+	var priorities []model.Priority
+	for _, value := range strings.Split(priorityStr, ",") {
+		priority, ok := model.ParsePriority(strings.TrimSpace(value))
+		if !ok {
+			return nil, ErrInvalidPriorityParameter
+		}
+		if !slices.Contains(priorities, priority) {
+			priorities = append(priorities, priority)
+		}
+	}
+
+	return priorities, nil
+}
+
+func ValidateTagsParam(tags []string) ([]string, error) {
+	normalized := model.NormalizeTags(tags)
+
+	for _, tag := range normalized {
+		if utf8.RuneCountInString(tag) > MaxTagLength {
+			return nil, ErrTagTooLong
+		}
+	}
+
+	return normalized, nil
+}
+
+func ValidateTagMatchParam(tagMatchStr string) (bool, error) {
+	switch tagMatchStr {
+	case "", "any":
+		return false, nil
+	case "all":
+		return true, nil
 	default:
-		return nil, ErrInvalidCompletedParameter
+		return false, ErrInvalidTagMatchParameter
+	}
+}
+
+func joinPriorities() string {
+	names := make([]string, len(model.Priorities))
+	for i, priority := range model.Priorities {
+		names[i] = string(priority)
 	}
+	return strings.Join(names, ", ")
 }
@@ -0,0 +1,134 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+)
+
+var (
+	ErrInvalidJSONFormat     = errors.New("Invalid JSON format")
+	ErrMergePatchNotObject   = errors.New("Merge patch must be a JSON object")
+	ErrMissingTaskField      = errors.New("PUT requires the full task representation; missing field")
+	ErrReadOnlyTaskField     = errors.New("Field cannot be changed")
+	ErrUnknownTaskField      = errors.New("Unknown field")
+	ErrInvalidTaskFieldValue = errors.New("Invalid value for field")
+
+	// ReadOnlyTaskFields may appear in a PUT body, so a fetched task can be
+	// sent back as is, but they are never written. Checklist items are
+	// changed through their own endpoints or a JSON Patch on /items.
+	ReadOnlyTaskFields = []string{"id", "created_at", "updated_at", "owner_id", "version", "items", "progress", "deleted_at"}
+)
+
+// ValidateReplaceTaskRequest parses a PUT body. Every updatable field must be
+// present; null clears it.
+func ValidateReplaceTaskRequest(body []byte) (dto.UpdateTaskRequest, error) {
+	fields, err := decodeTaskObject(body)
+	if err != nil {
+		return dto.UpdateTaskRequest{}, err
+	}
+
+	for _, name := range dto.UpdatableTaskFields {
+		if _, ok := fields[name]; !ok {
+			return dto.UpdateTaskRequest{}, fmt.Errorf("%w '%s'", ErrMissingTaskField, name)
+		}
+	}
+
+	req, err := decodeTaskFields(fields, true)
+	if err != nil {
+		return req, err
+	}
+
+	return req, ValidateUpdateTaskRequest(req)
+}
+
+// ValidateMergePatchTaskRequest parses an RFC 7396 merge patch. Only the
+// fields present are written; null clears a field.
+func ValidateMergePatchTaskRequest(body []byte) (dto.UpdateTaskRequest, error) {
+	fields, err := decodeTaskObject(body)
+	if err != nil {
+		return dto.UpdateTaskRequest{}, err
+	}
+
+	req, err := decodeTaskFields(fields, false)
+	if err != nil {
+		return req, err
+	}
+
+	return req, ValidateUpdateTaskRequest(req)
+}
+
+func decodeTaskObject(body []byte) (map[string]json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] != '{' {
+		return nil, ErrMergePatchNotObject
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &fields); err != nil {
+		return nil, ErrInvalidJSONFormat
+	}
+
+	return fields, nil
+}
+
+func decodeTaskFields(fields map[string]json.RawMessage, allowReadOnly bool) (dto.UpdateTaskRequest, error) {
+	req := dto.UpdateTaskRequest{UpdateMask: []string{}}
+
+	for name, raw := range fields {
+		if slices.Contains(ReadOnlyTaskFields, name) {
+			if allowReadOnly {
+				continue
+			}
+			return req, fmt.Errorf("%w '%s'", ErrReadOnlyTaskField, name)
+		}
+		if !slices.Contains(dto.UpdatableTaskFields, name) {
+			return req, fmt.Errorf("%w '%s'", ErrUnknownTaskField, name)
+		}
+
+		req.UpdateMask = append(req.UpdateMask, name)
+		if string(bytes.TrimSpace(raw)) == "null" {
+			continue
+		}
+
+		var err error
+		switch name {
+		case dto.TaskFieldTitle:
+			req.Title, err = decodeTaskField[string](raw)
+		case dto.TaskFieldDescription:
+			req.Description, err = decodeTaskField[string](raw)
+		case dto.TaskFieldCompleted:
+			req.Completed, err = decodeTaskField[bool](raw)
+		case dto.TaskFieldDueAt:
+			req.DueAt, err = decodeTaskField[time.Time](raw)
+		case dto.TaskFieldPriority:
+			req.Priority, err = decodeTaskField[string](raw)
+		case dto.TaskFieldTags:
+			req.Tags, err = decodeTaskField[[]string](raw)
+		case dto.TaskFieldListID:
+			req.ListID, err = decodeTaskField[string](raw)
+		}
+		if err != nil {
+			return req, fmt.Errorf("%w '%s'", ErrInvalidTaskFieldValue, name)
+		}
+	}
+
+	slices.SortFunc(req.UpdateMask, func(a, b string) int {
+		return slices.Index(dto.UpdatableTaskFields, a) - slices.Index(dto.UpdatableTaskFields, b)
+	})
+
+	return req, nil
+}
+
+func decodeTaskField[T any](raw json.RawMessage) (*T, error) {
+	var value T
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
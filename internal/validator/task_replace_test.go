@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+const fullTaskBody = `{
+	"title": "Pack",
+	"description": "For the trip",
+	"completed": false,
+	"due_at": null,
+	"priority": "high",
+	"tags": ["trip"],
+	"list_id": null
+}`
+
+func TestValidateReplaceTaskRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantMask []string
+		wantErr  error
+	}{
+		{
+			name:     "full representation",
+			body:     fullTaskBody,
+			wantMask: []string{"title", "description", "completed", "due_at", "priority", "tags", "list_id"},
+		},
+		{
+			name: "fetched task is accepted as is",
+			body: `{
+				"id": "task-1", "created_at": "2024-05-01T12:00:00Z", "updated_at": "2024-05-01T12:00:00Z",
+				"owner_id": "alice", "version": 3, "items": [], "progress": {"done": 0, "total": 0},
+				"title": "Pack", "description": "", "completed": true, "due_at": null,
+				"priority": "low", "tags": [], "list_id": "groceries"
+			}`,
+			wantMask: []string{"title", "description", "completed", "due_at", "priority", "tags", "list_id"},
+		},
+		{
+			name: "fetched trashed task is accepted as is",
+			body: `{
+				"id": "task-1", "created_at": "2024-05-01T12:00:00Z", "updated_at": "2024-05-01T12:00:00Z",
+				"owner_id": "alice", "version": 3, "deleted_at": "2024-05-02T12:00:00Z", "items": [], "progress": {"done": 0, "total": 0},
+				"title": "Pack", "description": "", "completed": true, "due_at": null,
+				"priority": "low", "tags": [], "list_id": "groceries"
+			}`,
+			wantMask: []string{"title", "description", "completed", "due_at", "priority", "tags", "list_id"},
+		},
+		{name: "missing field", body: `{"title": "Pack"}`, wantErr: ErrMissingTaskField},
+		{name: "null title", body: `{"title": null, "description": "", "completed": false, "due_at": null, "priority": null, "tags": null, "list_id": null}`, wantErr: ErrTitleEmpty},
+		{name: "unknown field", body: `{"title": "Pack", "description": "", "completed": false, "due_at": null, "priority": null, "tags": null, "list_id": null, "colour": "red"}`, wantErr: ErrUnknownTaskField},
+		{name: "wrong type", body: `{"title": "Pack", "description": "", "completed": "yes", "due_at": null, "priority": null, "tags": null, "list_id": null}`, wantErr: ErrInvalidTaskFieldValue},
+		{name: "not an object", body: `["title"]`, wantErr: ErrMergePatchNotObject},
+		{name: "malformed", body: `{"title": `, wantErr: ErrInvalidJSONFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := ValidateReplaceTaskRequest([]byte(tt.body))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !slices.Equal(req.UpdateMask, tt.wantMask) {
+				t.Errorf("update mask = %v, want %v", req.UpdateMask, tt.wantMask)
+			}
+		})
+	}
+}
+
+func TestValidateReplaceTaskRequestClearsNullFields(t *testing.T) {
+	req, err := ValidateReplaceTaskRequest([]byte(fullTaskBody))
+	if err != nil {
+		t.Fatalf("error = %v", err)
+	}
+
+	if req.DueAt != nil || req.ListID != nil {
+		t.Errorf("null fields were decoded: due_at=%v list_id=%v", req.DueAt, req.ListID)
+	}
+	if !req.HasPath("due_at") || !req.HasPath("list_id") {
+		t.Errorf("null fields are missing from the update mask %v", req.UpdateMask)
+	}
+	if req.Title == nil || *req.Title != "Pack" || req.Priority == nil || *req.Priority != "high" {
+		t.Errorf("fields were not decoded: %+v", req)
+	}
+}
+
+func TestValidateMergePatchTaskRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantMask []string
+		wantErr  error
+	}{
+		{name: "single field", body: `{"completed": true}`, wantMask: []string{"completed"}},
+		{name: "clear a field", body: `{"due_at": null}`, wantMask: []string{"due_at"}},
+		{name: "mask follows field order", body: `{"tags": ["a"], "title": "Pack"}`, wantMask: []string{"title", "tags"}},
+		{name: "empty patch", body: `{}`, wantErr: ErrNoFieldsProvided},
+		{name: "read-only field", body: `{"version": 4}`, wantErr: ErrReadOnlyTaskField},
+		{name: "unknown field", body: `{"colour": "red"}`, wantErr: ErrUnknownTaskField},
+		{name: "clearing the title", body: `{"title": null}`, wantErr: ErrTitleEmpty},
+		{name: "empty title", body: `{"title": ""}`, wantErr: ErrTitleEmpty},
+		{name: "bad due date", body: `{"due_at": "tomorrow"}`, wantErr: ErrInvalidTaskFieldValue},
+		{name: "not an object", body: `"title"`, wantErr: ErrMergePatchNotObject},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := ValidateMergePatchTaskRequest([]byte(tt.body))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !slices.Equal(req.UpdateMask, tt.wantMask) {
+				t.Errorf("update mask = %v, want %v", req.UpdateMask, tt.wantMask)
+			}
+		})
+	}
+}
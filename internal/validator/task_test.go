@@ -0,0 +1,387 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+	"github.com/Raisondetr3/checklist-api-service/pkg/dto"
+)
+
+func TestValidateLimitParam(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int
+		wantErr error
+	}{
+		{name: "default", value: "", want: DefaultPageSize},
+		{name: "smallest", value: "1", want: 1},
+		{name: "largest", value: "100", want: MaxPageSize},
+		{name: "zero", value: "0", wantErr: ErrInvalidLimitParameter},
+		{name: "negative", value: "-5", wantErr: ErrInvalidLimitParameter},
+		{name: "over the maximum", value: "101", wantErr: ErrInvalidLimitParameter},
+		{name: "not a number", value: "ten", wantErr: ErrInvalidLimitParameter},
+		{name: "fraction", value: "2.5", wantErr: ErrInvalidLimitParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateLimitParam(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("limit = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateCursorParam(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr error
+	}{
+		{name: "empty", value: ""},
+		{name: "opaque token", value: "eyJpZCI6InRhc2stNDIifQ"},
+		{name: "at the maximum", value: strings.Repeat("a", MaxCursorLength)},
+		{name: "too long", value: strings.Repeat("a", MaxCursorLength+1), wantErr: ErrInvalidCursorParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateCursorParam(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.value {
+				t.Errorf("cursor = %q, want %q", got, tt.value)
+			}
+		})
+	}
+}
+
+func TestValidateTimestampParam(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *time.Time
+		wantErr error
+	}{
+		{name: "empty", value: ""},
+		{name: "utc", value: "2025-03-01T10:00:00Z", want: ptr(time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC))},
+		{name: "offset", value: "2025-03-01T13:00:00+03:00", want: ptr(time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC))},
+		{name: "plus decoded as space", value: "2025-03-01T13:00:00 03:00", want: ptr(time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC))},
+		{name: "date only", value: "2025-03-01", wantErr: ErrInvalidTimestampFormat},
+		{name: "garbage", value: "yesterday", wantErr: ErrInvalidTimestampFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateTimestampParam("created_after", tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if want := "Invalid 'created_after' parameter. Use an RFC 3339 timestamp"; err.Error() != want {
+					t.Errorf("message = %q, want %q", err.Error(), want)
+				}
+				return
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTimeRange(t *testing.T) {
+	earlier := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+
+	tests := []struct {
+		name          string
+		after, before *time.Time
+		wantErr       error
+	}{
+		{name: "open", after: nil, before: nil},
+		{name: "only after", after: &earlier},
+		{name: "ordered", after: &earlier, before: &later},
+		{name: "equal", after: &earlier, before: &earlier, wantErr: ErrInvalidTimeRange},
+		{name: "reversed", after: &later, before: &earlier, wantErr: ErrInvalidTimeRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateTimeRange(tt.after, tt.before); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSortParam(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []dto.SortField
+		wantErr string
+	}{
+		{name: "default", value: "", want: DefaultSort},
+		{name: "single field gets id tiebreak", value: "title", want: []dto.SortField{{Field: "title"}, {Field: "id"}}},
+		{
+			name:  "descending and several fields",
+			value: "created_at,-updated_at,title",
+			want: []dto.SortField{
+				{Field: "created_at"},
+				{Field: "updated_at", Descending: true},
+				{Field: "title"},
+				{Field: "id"},
+			},
+		},
+		{name: "explicit id is not repeated", value: "-id", want: []dto.SortField{{Field: "id", Descending: true}}},
+		{name: "spaces around keys", value: " priority , -due_at ", want: []dto.SortField{{Field: "priority"}, {Field: "due_at", Descending: true}, {Field: "id"}}},
+		{name: "unknown field", value: "owner", wantErr: "unknown sort key 'owner'"},
+		{name: "field names are case sensitive", value: "Title", wantErr: "unknown sort key 'Title'"},
+		{name: "double minus", value: "--title", wantErr: "unknown sort key '-title'"},
+		{name: "duplicate field", value: "title,title", wantErr: "duplicate sort key 'title'"},
+		{name: "duplicate field in both directions", value: "title,-title", wantErr: "duplicate sort key 'title'"},
+		{name: "empty segment", value: "title,,id", wantErr: "empty sort key"},
+		{name: "trailing comma", value: "title,", wantErr: "empty sort key"},
+		{name: "bare minus", value: "-", wantErr: "empty sort key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateSortParam(tt.value)
+			if tt.wantErr != "" {
+				if !errors.Is(err, ErrInvalidSortParameter) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %v mentioning %q", err, ErrInvalidSortParameter, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("sort = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePriorityParam(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []model.Priority
+		wantErr error
+	}{
+		{name: "absent", value: ""},
+		{name: "single", value: "high", want: []model.Priority{model.PriorityHigh}},
+		{name: "list", value: "low,urgent", want: []model.Priority{model.PriorityLow, model.PriorityUrgent}},
+		{name: "spaces in list", value: " none , medium ", want: []model.Priority{model.PriorityNone, model.PriorityMedium}},
+		{name: "duplicates collapse", value: "high,high,low", want: []model.Priority{model.PriorityHigh, model.PriorityLow}},
+		{name: "unknown value", value: "critical", wantErr: ErrInvalidPriorityParameter},
+		{name: "one unknown in list", value: "low,critical", wantErr: ErrInvalidPriorityParameter},
+		{name: "upper case", value: "HIGH", wantErr: ErrInvalidPriorityParameter},
+		{name: "empty segment", value: "high,", wantErr: ErrInvalidPriorityParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidatePriorityParam(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("priorities = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskRequestPriority(t *testing.T) {
+	tests := []struct {
+		name     string
+		priority string
+		wantErr  error
+	}{
+		{name: "none", priority: "none"},
+		{name: "urgent", priority: "urgent"},
+		{name: "unknown", priority: "critical", wantErr: ErrInvalidPriority},
+		{name: "capitalized", priority: "High", wantErr: ErrInvalidPriority},
+		{name: "padded", priority: " low", wantErr: ErrInvalidPriority},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			create := dto.CreateTaskRequest{Title: "Pack", Priority: tt.priority}
+			if err := ValidateCreateTaskRequest(create); !errors.Is(err, tt.wantErr) {
+				t.Errorf("create error = %v, want %v", err, tt.wantErr)
+			}
+
+			update := dto.UpdateTaskRequest{Priority: &tt.priority}
+			if err := ValidateUpdateTaskRequest(update); !errors.Is(err, tt.wantErr) {
+				t.Errorf("update error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := ValidateCreateTaskRequest(dto.CreateTaskRequest{Title: "Pack"}); err != nil {
+		t.Errorf("create without priority: error = %v, want nil", err)
+	}
+	if want := "Invalid priority. Allowed values: none, low, medium, high, urgent"; ErrInvalidPriority.Error() != want {
+		t.Errorf("message = %q, want %q", ErrInvalidPriority.Error(), want)
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	manyTags := func(n int) []string {
+		tags := make([]string, n)
+		for i := range tags {
+			tags[i] = fmt.Sprintf("tag-%d", i)
+		}
+		return tags
+	}
+
+	tests := []struct {
+		name    string
+		tags    []string
+		wantErr error
+	}{
+		{name: "none", tags: nil},
+		{name: "at the count limit", tags: manyTags(MaxTagsPerTask)},
+		{name: "over the count limit", tags: manyTags(MaxTagsPerTask + 1), wantErr: ErrTooManyTags},
+		{name: "duplicates count once", tags: append(manyTags(MaxTagsPerTask), "TAG-0", " tag-1 ")},
+		{name: "blank tags do not count", tags: append(manyTags(MaxTagsPerTask), "", "  ")},
+		{name: "at the length limit", tags: []string{strings.Repeat("a", MaxTagLength)}},
+		{name: "length counts characters", tags: []string{strings.Repeat("é", MaxTagLength)}},
+		{name: "length ignores padding", tags: []string{"  " + strings.Repeat("a", MaxTagLength) + "  "}},
+		{name: "over the length limit", tags: []string{strings.Repeat("a", MaxTagLength+1)}, wantErr: ErrTagTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateTags(tt.tags); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateTagsParam(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr error
+	}{
+		{name: "absent", tags: nil, want: nil},
+		{name: "normalized", tags: []string{" Home", "home", "WORK"}, want: []string{"home", "work"}},
+		{name: "no count limit for filters", tags: slices.Repeat([]string{"a", "b"}, MaxTagsPerTask), want: []string{"a", "b"}},
+		{name: "too long", tags: []string{strings.Repeat("a", MaxTagLength+1)}, wantErr: ErrTagTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateTagsParam(tt.tags)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("tags = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTagMatchParam(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    bool
+		wantErr error
+	}{
+		{value: "", want: false},
+		{value: "any", want: false},
+		{value: "all", want: true},
+		{value: "ALL", wantErr: ErrInvalidTagMatchParameter},
+		{value: "some", wantErr: ErrInvalidTagMatchParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ValidateTagMatchParam(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("match all = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateDueAt(t *testing.T) {
+	tests := []struct {
+		name    string
+		dueAt   *time.Time
+		wantErr error
+	}{
+		{name: "absent", dueAt: nil},
+		{name: "tomorrow", dueAt: ptr(time.Now().Add(24 * time.Hour))},
+		{name: "in the past", dueAt: ptr(time.Date(2001, 9, 9, 0, 0, 0, 0, time.UTC))},
+		{name: "epoch", dueAt: ptr(MinDueDate)},
+		{name: "before the epoch", dueAt: ptr(MinDueDate.Add(-time.Second)), wantErr: ErrDueDateOutOfRange},
+		{name: "zero time", dueAt: ptr(time.Time{}), wantErr: ErrDueDateOutOfRange},
+		{name: "beyond the horizon", dueAt: ptr(time.Now().Add(MaxDueDateHorizon + time.Hour)), wantErr: ErrDueDateOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateDueAt(tt.dueAt); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+
+			create := dto.CreateTaskRequest{Title: "Pack", DueAt: tt.dueAt}
+			if err := ValidateCreateTaskRequest(create); !errors.Is(err, tt.wantErr) {
+				t.Errorf("create error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateOverdueParam(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    *bool
+		wantErr error
+	}{
+		{value: "", want: nil},
+		{value: "true", want: ptr(true)},
+		{value: "false", want: ptr(false)},
+		{value: "yes", wantErr: ErrInvalidOverdueParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ValidateOverdueParam(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("overdue = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
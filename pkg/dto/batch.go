@@ -0,0 +1,50 @@
+package dto
+
+import "encoding/json"
+
+const (
+	BatchOpCreate = "create"
+	BatchOpUpdate = "update"
+	BatchOpDelete = "delete"
+)
+
+type BatchTaskRequest struct {
+	Atomic     bool                        `json:"atomic"`
+	Operations []BatchTaskOperationRequest `json:"operations"`
+}
+
+// BatchTaskOperationRequest is a single batch entry as sent by the client.
+// Task holds a CreateTaskRequest for creates and an UpdateTaskRequest for
+// updates; Version is the expected task version for updates and deletes.
+type BatchTaskOperationRequest struct {
+	Op      string          `json:"op"`
+	ID      string          `json:"id,omitempty"`
+	Version *int64          `json:"version,omitempty"`
+	Task    json.RawMessage `json:"task,omitempty"`
+
+	// Permanent skips the trash for delete operations.
+	Permanent bool `json:"permanent,omitempty"`
+}
+
+type BatchTaskOperation struct {
+	Op              string
+	TaskID          string
+	Create          *CreateTaskRequest
+	Update          *UpdateTaskRequest
+	ExpectedVersion *int64
+	Permanent       bool
+}
+
+type BatchTaskResult struct {
+	Index  int           `json:"index"`
+	Status int           `json:"status"`
+	Task   *TaskResponse `json:"task,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+type BatchTaskResponse struct {
+	Atomic    bool              `json:"atomic"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Results   []BatchTaskResult `json:"results"`
+}
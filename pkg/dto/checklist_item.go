@@ -0,0 +1,34 @@
+package dto
+
+type ChecklistItemResponse struct {
+	ID       string `json:"id"`
+	Text     string `json:"text"`
+	Done     bool   `json:"done"`
+	Position int    `json:"position"`
+}
+
+type ProgressResponse struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+type ChecklistItemListResponse struct {
+	Items    []ChecklistItemResponse `json:"items"`
+	Progress ProgressResponse        `json:"progress"`
+}
+
+type CreateChecklistItemRequest struct {
+	Text     string `json:"text"`
+	Done     bool   `json:"done"`
+	Position *int   `json:"position,omitempty"`
+}
+
+type UpdateChecklistItemRequest struct {
+	Text     *string `json:"text,omitempty"`
+	Done     *bool   `json:"done,omitempty"`
+	Position *int    `json:"position,omitempty"`
+}
+
+type ReorderChecklistItemsRequest struct {
+	ItemIDs []string `json:"item_ids"`
+}
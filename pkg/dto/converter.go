@@ -1,8 +1,13 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/Raisondetr3/checklist-api-service/internal/model"
 	pb "github.com/Raisondetr3/checklist-api-service/pkg/pb"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func ProtoToTaskResponse(protoTask *pb.Task) TaskResponse {
@@ -10,14 +15,7 @@ func ProtoToTaskResponse(protoTask *pb.Task) TaskResponse {
 		return TaskResponse{}
 	}
 
-	return TaskResponse{
-		ID:          protoTask.Id,
-		Title:       protoTask.Title,
-		Description: protoTask.Description,
-		Completed:   protoTask.Completed,
-		CreatedAt:   protoTask.CreatedAt.AsTime(),
-		UpdatedAt:   protoTask.UpdatedAt.AsTime(),
-	}
+	return TaskModelToResponse(ProtoToModelTask(protoTask))
 }
 
 func ProtoToTaskListResponse(protoResp *pb.ListTasksResponse) TaskListResponse {
@@ -41,6 +39,10 @@ func CreateTaskRequestToProto(dto CreateTaskRequest) *pb.CreateTaskRequest {
 	return &pb.CreateTaskRequest{
 		Title:       dto.Title,
 		Description: dto.Description,
+		DueAt:       timeToProto(dto.DueAt),
+		Priority:    PriorityToProto(model.Priority(dto.Priority)),
+		Tags:        model.NormalizeTags(dto.Tags),
+		ListId:      dto.ListID,
 	}
 }
 
@@ -58,6 +60,23 @@ func UpdateTaskRequestToProto(id string, dto UpdateTaskRequest) *pb.UpdateTaskRe
 	if dto.Completed != nil {
 		req.Completed = dto.Completed
 	}
+	if dto.DueAt != nil {
+		req.DueAt = timeToProto(dto.DueAt)
+	}
+	if dto.Priority != nil {
+		priority := PriorityToProto(model.Priority(*dto.Priority))
+		req.Priority = &priority
+	}
+	if dto.Tags != nil {
+		req.Tags = &pb.TagList{
+			Tags: model.NormalizeTags(*dto.Tags),
+		}
+	}
+	if dto.ListID != nil {
+		req.ListId = dto.ListID
+	}
+	req.ExpectedVersion = dto.ExpectedVersion
+	req.UpdateMask = &fieldmaskpb.FieldMask{Paths: dto.Paths()}
 
 	return req
 }
@@ -68,14 +87,172 @@ func GetTaskRequestToProto(id string) *pb.GetTaskRequest {
 	}
 }
 
-func DeleteTaskRequestToProto(id string) *pb.DeleteTaskRequest {
+func DeleteTaskRequestToProto(id string, expectedVersion *int64, permanent bool) *pb.DeleteTaskRequest {
 	return &pb.DeleteTaskRequest{
+		Id:              id,
+		ExpectedVersion: expectedVersion,
+		Permanent:       permanent,
+	}
+}
+
+func RestoreTaskRequestToProto(id string) *pb.RestoreTaskRequest {
+	return &pb.RestoreTaskRequest{
 		Id: id,
 	}
 }
 
-func ListTasksRequestToProto() *pb.ListTasksRequest {
-	return &pb.ListTasksRequest{}
+func PurgeDeletedTasksRequestToProto(deletedBefore time.Time) *pb.PurgeDeletedTasksRequest {
+	return &pb.PurgeDeletedTasksRequest{
+		DeletedBefore: timestamppb.New(deletedBefore),
+	}
+}
+
+func BatchTaskOperationsToProto(ops []BatchTaskOperation, atomic bool) *pb.BatchMutateRequest {
+	req := &pb.BatchMutateRequest{
+		Operations: make([]*pb.BatchOperation, 0, len(ops)),
+		Atomic:     atomic,
+	}
+
+	for _, op := range ops {
+		protoOp := &pb.BatchOperation{}
+		switch op.Op {
+		case BatchOpCreate:
+			protoOp.Operation = &pb.BatchOperation_Create{Create: CreateTaskRequestToProto(*op.Create)}
+		case BatchOpUpdate:
+			update := *op.Update
+			update.ExpectedVersion = op.ExpectedVersion
+			protoOp.Operation = &pb.BatchOperation_Update{Update: UpdateTaskRequestToProto(op.TaskID, update)}
+		case BatchOpDelete:
+			protoOp.Operation = &pb.BatchOperation_Delete{Delete: DeleteTaskRequestToProto(op.TaskID, op.ExpectedVersion, op.Permanent)}
+		}
+		req.Operations = append(req.Operations, protoOp)
+	}
+
+	return req
+}
+
+func BatchGetTasksRequestToProto(ids []string) *pb.BatchGetTasksRequest {
+	return &pb.BatchGetTasksRequest{Ids: ids}
+}
+
+func ListTasksRequestToProto(dto ListTasksRequest) *pb.ListTasksRequest {
+	sort := make([]*pb.SortSpec, len(dto.Sort))
+	for i, field := range dto.Sort {
+		sort[i] = &pb.SortSpec{
+			Field:      field.Field,
+			Descending: field.Descending,
+		}
+	}
+
+	priorities := make([]pb.Priority, len(dto.Priorities))
+	for i, priority := range dto.Priorities {
+		priorities[i] = PriorityToProto(priority)
+	}
+
+	return &pb.ListTasksRequest{
+		PageSize:  int32(dto.Limit),
+		PageToken: dto.Cursor,
+		Completed: dto.Completed,
+		Sort:      sort,
+		Query:     dto.Query,
+
+		CreatedAfter:  timeToProto(dto.CreatedAfter),
+		CreatedBefore: timeToProto(dto.CreatedBefore),
+		UpdatedAfter:  timeToProto(dto.UpdatedAfter),
+		UpdatedBefore: timeToProto(dto.UpdatedBefore),
+
+		Overdue:    dto.Overdue,
+		DueBefore:  timeToProto(dto.DueBefore),
+		Priorities: priorities,
+
+		Tags:         dto.Tags,
+		MatchAllTags: dto.MatchAllTags,
+
+		ListId:  dto.ListID,
+		Deleted: dto.Deleted,
+	}
+}
+
+func ListTagsRequestToProto() *pb.ListTagsRequest {
+	return &pb.ListTagsRequest{}
+}
+
+func timeToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func ProtoToTagCounts(protoTags []*pb.TagCount) []model.TagCount {
+	tags := make([]model.TagCount, len(protoTags))
+	for i, protoTag := range protoTags {
+		tags[i] = model.TagCount{
+			Tag:   protoTag.Tag,
+			Count: int(protoTag.Count),
+		}
+	}
+
+	return tags
+}
+
+func TagCountsToResponse(tags []model.TagCount) TagListResponse {
+	responses := make([]TagCountResponse, len(tags))
+	for i, tag := range tags {
+		responses[i] = TagCountResponse{
+			Tag:   tag.Tag,
+			Count: tag.Count,
+		}
+	}
+
+	return TagListResponse{
+		Tags: responses,
+	}
+}
+
+func nonNilTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+func PriorityToProto(priority model.Priority) pb.Priority {
+	switch priority {
+	case model.PriorityLow:
+		return pb.Priority_PRIORITY_LOW
+	case model.PriorityMedium:
+		return pb.Priority_PRIORITY_MEDIUM
+	case model.PriorityHigh:
+		return pb.Priority_PRIORITY_HIGH
+	case model.PriorityUrgent:
+		return pb.Priority_PRIORITY_URGENT
+	default:
+		return pb.Priority_PRIORITY_NONE
+	}
+}
+
+func ProtoToPriority(priority pb.Priority) model.Priority {
+	switch priority {
+	case pb.Priority_PRIORITY_LOW:
+		return model.PriorityLow
+	case pb.Priority_PRIORITY_MEDIUM:
+		return model.PriorityMedium
+	case pb.Priority_PRIORITY_HIGH:
+		return model.PriorityHigh
+	case pb.Priority_PRIORITY_URGENT:
+		return model.PriorityUrgent
+	default:
+		return model.PriorityNone
+	}
+}
+
+func protoToTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
 }
 
 func TaskModelToResponse(task *model.Task) TaskResponse {
@@ -90,6 +267,15 @@ func TaskModelToResponse(task *model.Task) TaskResponse {
 		Completed:   task.Completed,
 		CreatedAt:   task.CreatedAt,
 		UpdatedAt:   task.UpdatedAt,
+		DueAt:       task.DueAt,
+		Priority:    string(task.Priority),
+		Tags:        nonNilTags(task.Tags),
+		Items:       ChecklistItemsToResponse(task.Items),
+		ListID:      task.ListID,
+		OwnerID:     task.OwnerID,
+		Version:     task.Version,
+		DeletedAt:   task.DeletedAt,
+		Progress:    ProgressToResponse(task.Progress()),
 	}
 }
 
@@ -111,7 +297,14 @@ func TaskModelsToResponse(tasks []*model.Task) TaskListResponse {
 }
 
 func CreateTaskRequestToModel(dto CreateTaskRequest) *model.Task {
-	return model.NewTask(dto.Title, dto.Description)
+	task := model.NewTask(dto.Title, dto.Description)
+	task.DueAt = dto.DueAt
+	if dto.Priority != "" {
+		task.Priority = model.Priority(dto.Priority)
+	}
+	task.Tags = model.NormalizeTags(dto.Tags)
+	task.ListID = dto.ListID
+	return task
 }
 
 func ProtoToModelTask(protoTask *pb.Task) *model.Task {
@@ -126,6 +319,15 @@ func ProtoToModelTask(protoTask *pb.Task) *model.Task {
 		Completed:   protoTask.Completed,
 		CreatedAt:   protoTask.CreatedAt.AsTime(),
 		UpdatedAt:   protoTask.UpdatedAt.AsTime(),
+		DueAt:       protoToTime(protoTask.DueAt),
+		Priority:    ProtoToPriority(protoTask.Priority),
+		Tags:        protoTask.Tags,
+		Items:       ProtoToChecklistItems(protoTask.Items),
+		ListID:      protoTask.ListId,
+		OwnerID:     protoTask.OwnerId,
+		Shares:      ProtoToShares(protoTask.Shares),
+		Version:     protoTask.Version,
+		DeletedAt:   protoToTime(protoTask.DeletedAt),
 	}
 }
 
@@ -140,4 +342,313 @@ func ProtoToModelTasks(protoTasks []*pb.Task) []*model.Task {
 	}
 
 	return tasks
-}
\ No newline at end of file
+}
+
+func ProtoToChecklistItem(protoItem *pb.ChecklistItem) model.ChecklistItem {
+	if protoItem == nil {
+		return model.ChecklistItem{}
+	}
+
+	return model.ChecklistItem{
+		ID:       protoItem.Id,
+		Text:     protoItem.Text,
+		Done:     protoItem.Done,
+		Position: int(protoItem.Position),
+	}
+}
+
+func ProtoToChecklistItems(protoItems []*pb.ChecklistItem) []model.ChecklistItem {
+	items := make([]model.ChecklistItem, len(protoItems))
+	for i, protoItem := range protoItems {
+		items[i] = ProtoToChecklistItem(protoItem)
+	}
+
+	return items
+}
+
+func ChecklistItemToResponse(item model.ChecklistItem) ChecklistItemResponse {
+	return ChecklistItemResponse{
+		ID:       item.ID,
+		Text:     item.Text,
+		Done:     item.Done,
+		Position: item.Position,
+	}
+}
+
+func ChecklistItemsToResponse(items []model.ChecklistItem) []ChecklistItemResponse {
+	responses := make([]ChecklistItemResponse, len(items))
+	for i, item := range items {
+		responses[i] = ChecklistItemToResponse(item)
+	}
+
+	return responses
+}
+
+func ChecklistItemsToListResponse(items []model.ChecklistItem) ChecklistItemListResponse {
+	task := model.Task{Items: items}
+
+	return ChecklistItemListResponse{
+		Items:    ChecklistItemsToResponse(items),
+		Progress: ProgressToResponse(task.Progress()),
+	}
+}
+
+func ProgressToResponse(progress model.Progress) ProgressResponse {
+	return ProgressResponse{
+		Done:  progress.Done,
+		Total: progress.Total,
+	}
+}
+
+func ListChecklistItemsRequestToProto(taskID string) *pb.ListChecklistItemsRequest {
+	return &pb.ListChecklistItemsRequest{
+		TaskId: taskID,
+	}
+}
+
+func GetChecklistItemRequestToProto(taskID, itemID string) *pb.GetChecklistItemRequest {
+	return &pb.GetChecklistItemRequest{
+		TaskId: taskID,
+		ItemId: itemID,
+	}
+}
+
+func CreateChecklistItemRequestToProto(taskID string, dto CreateChecklistItemRequest) *pb.CreateChecklistItemRequest {
+	return &pb.CreateChecklistItemRequest{
+		TaskId:   taskID,
+		Text:     dto.Text,
+		Done:     dto.Done,
+		Position: intToProto(dto.Position),
+	}
+}
+
+func UpdateChecklistItemRequestToProto(taskID, itemID string, dto UpdateChecklistItemRequest) *pb.UpdateChecklistItemRequest {
+	return &pb.UpdateChecklistItemRequest{
+		TaskId:   taskID,
+		ItemId:   itemID,
+		Text:     dto.Text,
+		Done:     dto.Done,
+		Position: intToProto(dto.Position),
+	}
+}
+
+func DeleteChecklistItemRequestToProto(taskID, itemID string) *pb.DeleteChecklistItemRequest {
+	return &pb.DeleteChecklistItemRequest{
+		TaskId: taskID,
+		ItemId: itemID,
+	}
+}
+
+func ReorderChecklistItemsRequestToProto(taskID string, dto ReorderChecklistItemsRequest) *pb.ReorderChecklistItemsRequest {
+	return &pb.ReorderChecklistItemsRequest{
+		TaskId:  taskID,
+		ItemIds: dto.ItemIDs,
+	}
+}
+
+func intToProto(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	converted := int32(*v)
+	return &converted
+}
+
+func ProtoToModelList(protoList *pb.List) *model.List {
+	if protoList == nil {
+		return nil
+	}
+
+	return &model.List{
+		ID:          protoList.Id,
+		Name:        protoList.Name,
+		Description: protoList.Description,
+		TaskCount:   int(protoList.TaskCount),
+		CreatedAt:   protoList.CreatedAt.AsTime(),
+		UpdatedAt:   protoList.UpdatedAt.AsTime(),
+		OwnerID:     protoList.OwnerId,
+		Shares:      ProtoToShares(protoList.Shares),
+	}
+}
+
+func ProtoToModelLists(protoLists []*pb.List) []*model.List {
+	lists := make([]*model.List, len(protoLists))
+	for i, protoList := range protoLists {
+		lists[i] = ProtoToModelList(protoList)
+	}
+	return lists
+}
+
+func ListModelToResponse(list *model.List) ListResponse {
+	if list == nil {
+		return ListResponse{}
+	}
+
+	return ListResponse{
+		ID:          list.ID,
+		Name:        list.Name,
+		Description: list.Description,
+		TaskCount:   list.TaskCount,
+		OwnerID:     list.OwnerID,
+		CreatedAt:   list.CreatedAt,
+		UpdatedAt:   list.UpdatedAt,
+	}
+}
+
+func ListPageToResponse(page *model.ListPage) ListCollectionResponse {
+	lists := make([]ListResponse, len(page.Lists))
+	for i, list := range page.Lists {
+		lists[i] = ListModelToResponse(list)
+	}
+
+	return ListCollectionResponse{
+		Lists:      lists,
+		TotalCount: page.TotalCount,
+		NextCursor: page.NextCursor,
+	}
+}
+
+func CreateListRequestToProto(dto CreateListRequest) *pb.CreateListRequest {
+	return &pb.CreateListRequest{
+		Name:        dto.Name,
+		Description: dto.Description,
+	}
+}
+
+func GetListRequestToProto(id string) *pb.GetListRequest {
+	return &pb.GetListRequest{
+		Id: id,
+	}
+}
+
+func UpdateListRequestToProto(id string, dto UpdateListRequest) *pb.UpdateListRequest {
+	return &pb.UpdateListRequest{
+		Id:          id,
+		Name:        dto.Name,
+		Description: dto.Description,
+	}
+}
+
+func DeleteListRequestToProto(id string, cascade bool) *pb.DeleteListRequest {
+	return &pb.DeleteListRequest{
+		Id:      id,
+		Cascade: cascade,
+	}
+}
+
+func ListListsRequestToProto(dto ListListsRequest) *pb.ListListsRequest {
+	return &pb.ListListsRequest{
+		PageSize:  int32(dto.Limit),
+		PageToken: dto.Cursor,
+	}
+}
+
+func ProtoToShare(protoShare *pb.Share) model.Share {
+	if protoShare == nil {
+		return model.Share{}
+	}
+
+	return model.Share{
+		UserID:    protoShare.UserId,
+		Role:      ProtoToRole(protoShare.Role),
+		GrantedBy: protoShare.GrantedBy,
+		CreatedAt: protoShare.CreatedAt.AsTime(),
+	}
+}
+
+func ProtoToShares(protoShares []*pb.Share) []model.Share {
+	shares := make([]model.Share, len(protoShares))
+	for i, protoShare := range protoShares {
+		shares[i] = ProtoToShare(protoShare)
+	}
+	return shares
+}
+
+func ShareToResponse(share model.Share) ShareResponse {
+	return ShareResponse{
+		UserID:    share.UserID,
+		Role:      string(share.Role),
+		GrantedBy: share.GrantedBy,
+		CreatedAt: share.CreatedAt,
+	}
+}
+
+func SharesToListResponse(ownerID string, shares []model.Share) ShareListResponse {
+	responses := make([]ShareResponse, len(shares))
+	for i, share := range shares {
+		responses[i] = ShareToResponse(share)
+	}
+
+	return ShareListResponse{
+		OwnerID: ownerID,
+		Shares:  responses,
+	}
+}
+
+func RoleToProto(role model.Role) pb.ShareRole {
+	switch role {
+	case model.RoleViewer:
+		return pb.ShareRole_SHARE_ROLE_VIEWER
+	case model.RoleEditor:
+		return pb.ShareRole_SHARE_ROLE_EDITOR
+	case model.RoleOwner:
+		return pb.ShareRole_SHARE_ROLE_OWNER
+	default:
+		return pb.ShareRole_SHARE_ROLE_UNSPECIFIED
+	}
+}
+
+func ProtoToRole(role pb.ShareRole) model.Role {
+	switch role {
+	case pb.ShareRole_SHARE_ROLE_VIEWER:
+		return model.RoleViewer
+	case pb.ShareRole_SHARE_ROLE_EDITOR:
+		return model.RoleEditor
+	case pb.ShareRole_SHARE_ROLE_OWNER:
+		return model.RoleOwner
+	default:
+		return ""
+	}
+}
+
+func ListSharesRequestToProto(taskID string) *pb.ListSharesRequest {
+	return &pb.ListSharesRequest{
+		TaskId: taskID,
+	}
+}
+
+func ShareTaskRequestToProto(taskID string, dto ShareTaskRequest) *pb.ShareTaskRequest {
+	return &pb.ShareTaskRequest{
+		TaskId: taskID,
+		UserId: dto.UserID,
+		Role:   RoleToProto(model.Role(dto.Role)),
+	}
+}
+
+func UnshareTaskRequestToProto(taskID, userID string) *pb.UnshareTaskRequest {
+	return &pb.UnshareTaskRequest{
+		TaskId: taskID,
+		UserId: userID,
+	}
+}
+
+func ListListSharesRequestToProto(listID string) *pb.ListListSharesRequest {
+	return &pb.ListListSharesRequest{
+		ListId: listID,
+	}
+}
+
+func ShareListRequestToProto(listID string, dto ShareTaskRequest) *pb.ShareListRequest {
+	return &pb.ShareListRequest{
+		ListId: listID,
+		UserId: dto.UserID,
+		Role:   RoleToProto(model.Role(dto.Role)),
+	}
+}
+
+func UnshareListRequestToProto(listID, userID string) *pb.UnshareListRequest {
+	return &pb.UnshareListRequest{
+		ListId: listID,
+		UserId: userID,
+	}
+}
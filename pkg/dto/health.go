@@ -5,4 +5,9 @@ import "time"
 type HealthStatus struct {
 	Status    string        `json:"status"`
 	Timestamp time.Time     `json:"timestamp"`
+	Outbox    *OutboxStatus `json:"outbox,omitempty"`
+}
+
+type OutboxStatus struct {
+	QueueDepth int `json:"queue_depth"`
 }
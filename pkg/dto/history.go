@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/history"
+)
+
+type FieldChangeResponse struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+type RevisionResponse struct {
+	ID        string                `json:"id"`
+	Version   int64                 `json:"version"`
+	Actor     string                `json:"actor"`
+	Operation string                `json:"operation"`
+	Timestamp time.Time             `json:"timestamp"`
+	Changes   []FieldChangeResponse `json:"changes"`
+}
+
+type TaskHistoryResponse struct {
+	TaskID    string             `json:"task_id"`
+	Revisions []RevisionResponse `json:"revisions"`
+}
+
+func RevisionsToHistoryResponse(taskID string, revisions []history.Revision) TaskHistoryResponse {
+	response := TaskHistoryResponse{
+		TaskID:    taskID,
+		Revisions: make([]RevisionResponse, len(revisions)),
+	}
+
+	for i, revision := range revisions {
+		changes := make([]FieldChangeResponse, len(revision.Changes))
+		for j, change := range revision.Changes {
+			changes[j] = FieldChangeResponse{
+				Field:  change.Field,
+				Before: change.Before,
+				After:  change.After,
+			}
+		}
+
+		response.Revisions[i] = RevisionResponse{
+			ID:        revision.ID,
+			Version:   revision.Version,
+			Actor:     revision.Actor,
+			Operation: revision.Operation,
+			Timestamp: revision.Timestamp,
+			Changes:   changes,
+		}
+	}
+
+	return response
+}
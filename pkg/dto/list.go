@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+type CreateListRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type UpdateListRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+func (r UpdateListRequest) IsEmpty() bool {
+	return r.Name == nil && r.Description == nil
+}
+
+type ListResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	TaskCount   int       `json:"task_count"`
+	OwnerID     string    `json:"owner_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ListCollectionResponse struct {
+	Lists      []ListResponse `json:"lists"`
+	TotalCount int            `json:"total_count"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+type ListListsRequest struct {
+	Limit  int
+	Cursor string
+}
+
+type DeleteListResponse struct {
+	Success      bool `json:"success"`
+	DeletedTasks int  `json:"deleted_tasks"`
+}
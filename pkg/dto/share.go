@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+type ShareTaskRequest struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+type ShareResponse struct {
+	UserID    string    `json:"user_id"`
+	Role      string    `json:"role"`
+	GrantedBy string    `json:"granted_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ShareListResponse struct {
+	OwnerID string          `json:"owner_id"`
+	Shares  []ShareResponse `json:"shares"`
+}
@@ -1,39 +1,173 @@
 package dto
 
-import "time"
+import (
+	"slices"
+	"time"
+
+	"github.com/Raisondetr3/checklist-api-service/internal/model"
+)
 
 const (
-	EventTaskCreated = "task.created"
-	EventTaskUpdated = "task.updated"
-	EventTaskDeleted = "task.deleted"
-	EventTaskViewed  = "task.viewed"
-	EventTasksListed = "tasks.listed"
+	EventTaskCreated  = "task.created"
+	EventTaskUpdated  = "task.updated"
+	EventTaskDeleted  = "task.deleted"
+	EventTaskRestored = "task.restored"
+	EventTaskViewed   = "task.viewed"
+	EventTasksListed  = "tasks.listed"
 )
 
 type CreateTaskRequest struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	Priority    string     `json:"priority,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	ListID      string     `json:"list_id,omitempty"`
 }
 
 type TaskResponse struct {
-	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Completed   bool      `json:"completed"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Completed   bool       `json:"completed"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DueAt       *time.Time `json:"due_at"`
+	Priority    string     `json:"priority"`
+	Tags        []string   `json:"tags"`
+	ListID      string     `json:"list_id"`
+	OwnerID     string     `json:"owner_id"`
+	Version     int64      `json:"version"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+
+	Items    []ChecklistItemResponse `json:"items"`
+	Progress ProgressResponse        `json:"progress"`
 }
 
 type UpdateTaskRequest struct {
-	Title       *string `json:"title,omitempty"`
-	Description *string `json:"description,omitempty"`
-	Completed   *bool   `json:"completed,omitempty"`
+	Title       *string    `json:"title,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	Completed   *bool      `json:"completed,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	Priority    *string    `json:"priority,omitempty"`
+	Tags        *[]string  `json:"tags,omitempty"`
+	ListID      *string    `json:"list_id,omitempty"`
+
+	// ExpectedVersion comes from the If-Match header, not the body.
+	ExpectedVersion *int64 `json:"-"`
+
+	// UpdateMask lists the fields to write when it is set. Listed fields
+	// whose value is nil are cleared; without a mask only non-nil fields
+	// are written.
+	UpdateMask []string `json:"-"`
+}
+
+const (
+	TaskFieldTitle       = "title"
+	TaskFieldDescription = "description"
+	TaskFieldCompleted   = "completed"
+	TaskFieldDueAt       = "due_at"
+	TaskFieldPriority    = "priority"
+	TaskFieldTags        = "tags"
+	TaskFieldListID      = "list_id"
+)
+
+var UpdatableTaskFields = []string{
+	TaskFieldTitle,
+	TaskFieldDescription,
+	TaskFieldCompleted,
+	TaskFieldDueAt,
+	TaskFieldPriority,
+	TaskFieldTags,
+	TaskFieldListID,
+}
+
+func (r UpdateTaskRequest) IsEmpty() bool {
+	return len(r.Paths()) == 0
+}
+
+// Paths returns the field mask of the update.
+func (r UpdateTaskRequest) Paths() []string {
+	if r.UpdateMask != nil {
+		return r.UpdateMask
+	}
+
+	var paths []string
+	if r.Title != nil {
+		paths = append(paths, TaskFieldTitle)
+	}
+	if r.Description != nil {
+		paths = append(paths, TaskFieldDescription)
+	}
+	if r.Completed != nil {
+		paths = append(paths, TaskFieldCompleted)
+	}
+	if r.DueAt != nil {
+		paths = append(paths, TaskFieldDueAt)
+	}
+	if r.Priority != nil {
+		paths = append(paths, TaskFieldPriority)
+	}
+	if r.Tags != nil {
+		paths = append(paths, TaskFieldTags)
+	}
+	if r.ListID != nil {
+		paths = append(paths, TaskFieldListID)
+	}
+
+	return paths
+}
+
+func (r UpdateTaskRequest) HasPath(path string) bool {
+	return slices.Contains(r.Paths(), path)
 }
 
 type TaskListResponse struct {
-	Tasks []TaskResponse `json:"tasks"`
+	Tasks      []TaskResponse `json:"tasks"`
+	TotalCount int            `json:"total_count"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+type ListTasksRequest struct {
+	Limit     int
+	Cursor    string
+	Completed *bool
+	Sort      []SortField
+	Query     string
+
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedAfter  *time.Time
+	UpdatedBefore *time.Time
+
+	Overdue    *bool
+	DueBefore  *time.Time
+	Priorities []model.Priority
+
+	Tags         []string
+	MatchAllTags bool
+
+	ListID string
+
+	// Deleted lists the trash instead of live tasks.
+	Deleted bool
+}
+
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+type TagCountResponse struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+type TagListResponse struct {
+	Tags []TagCountResponse `json:"tags"`
 }
 
 type DeleteTaskResponse struct {
-	Success bool `json:"success"`
+	Success   bool `json:"success"`
+	Permanent bool `json:"permanent"`
 }
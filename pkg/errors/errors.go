@@ -16,6 +16,7 @@ var (
 	ErrValidationFailed    = errors.New("validation failed")
 	ErrServiceUnavailable  = errors.New("service temporarily unavailable")
 	ErrInternalError       = errors.New("internal server error")
+	ErrListNotEmpty        = errors.New("list still contains tasks; move them to another list or delete with cascade=true")
 )
 
 func HTTPStatusFromError(err error) int {
@@ -32,7 +33,7 @@ func HTTPStatusFromError(err error) int {
 		return http.StatusNotFound
 	case isValidationError(err):
 		return http.StatusBadRequest
-	case isAlreadyExistsError(err):
+	case isAlreadyExistsError(err), isConflictError(err):
 		return http.StatusConflict
 	case isServiceUnavailableError(err):
 		return http.StatusServiceUnavailable
@@ -61,7 +62,7 @@ func grpcCodeToHTTPStatus(code codes.Code) int {
 		return http.StatusBadRequest
 	case codes.NotFound:
 		return http.StatusNotFound
-	case codes.AlreadyExists:
+	case codes.AlreadyExists, codes.Aborted:
 		return http.StatusConflict
 	case codes.PermissionDenied:
 		return http.StatusForbidden
@@ -124,6 +125,10 @@ func isAlreadyExistsError(err error) bool {
 		strings.Contains(err.Error(), "already exists")
 }
 
+func isConflictError(err error) bool {
+	return errors.Is(err, ErrListNotEmpty)
+}
+
 func isServiceUnavailableError(err error) bool {
 	return errors.Is(err, ErrServiceUnavailable) ||
 		strings.Contains(err.Error(), "unavailable") ||
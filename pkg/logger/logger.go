@@ -96,4 +96,17 @@ func LogError(ctx context.Context, err error, operation string, additionalFields
 	attrs = append(attrs, additionalFields...)
 
 	slog.LogAttrs(ctx, slog.LevelError, "Operation Error", attrs...)
-}
\ No newline at end of file
+}
+
+type contextKey string
+
+const requestIDKey contextKey = "request_id"
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}